@@ -2,6 +2,7 @@
 package convert
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/golang/protobuf/ptypes/timestamp"
@@ -13,11 +14,24 @@ var (
 	ErrUnableToConvertNilToStruct = errors.New(`unable to convert nil to [struct,array,slice,ptr]`)
 	// ErrUnableToConvertValueToStruct - value  cannot be converted to struct
 	ErrUnableToConvertValueToStruct = errors.New(`unable to convert value to struct`)
+	// ErrUnableToConvertValueToBigInt - value cannot be converted to big.Int
+	ErrUnableToConvertValueToBigInt = errors.New(`unable to convert value to big.Int`)
+	// ErrUnableToConvertNilBigInt - nil *big.Int cannot be converted to bytes
+	ErrUnableToConvertNilBigInt = errors.New(`unable to convert nil big.Int`)
 )
 
 const TypeInt = 1
 const TypeString = ``
 const TypeBool = true
+const TypeInt64 = int64(1)
+const TypeUint64 = uint64(1)
+
+var (
+	// TypeBigInt target type for converting to arbitrary-precision integer, typed nil - can't be modified
+	TypeBigInt = (*big.Int)(nil)
+	// TypeTime target type for converting to time.Time (RFC 3339 string representation)
+	TypeTime = time.Time{}
+)
 
 type (
 	// FromByter interface supports FromBytes func for converting from slice of bytes to target type
@@ -35,3 +49,8 @@ type (
 func TimestampToTime(ts *timestamp.Timestamp) time.Time {
 	return time.Unix(ts.GetSeconds(), int64(ts.GetNanos()))
 }
+
+// TimeToTimestamp converts time.Time to timestamp
+func TimeToTimestamp(t time.Time) *timestamp.Timestamp {
+	return &timestamp.Timestamp{Seconds: t.Unix(), Nanos: int32(t.Nanosecond())}
+}
@@ -1,7 +1,9 @@
 package convert_test
 
 import (
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/optherium/cckit/convert"
 
@@ -51,4 +53,61 @@ var _ = Describe(`Convert`, func() {
 		Expect(bNil).To(Equal([]byte{}))
 	})
 
+	It(`Int64`, func() {
+		b, err := convert.ToBytes(int64(-9007199254740993))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(Equal([]byte(`-9007199254740993`)))
+
+		i, err := convert.FromBytes(b, convert.TypeInt64)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(i.(int64)).To(Equal(int64(-9007199254740993)))
+	})
+
+	It(`BigInt`, func() {
+		const Big = `123456789012345678901234567890`
+		i, err := convert.FromBytes([]byte(Big), convert.TypeBigInt)
+		Expect(err).NotTo(HaveOccurred())
+
+		b, err := convert.ToBytes(i)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(Equal([]byte(Big)))
+
+		_, err = convert.FromBytes([]byte(`12.5`), convert.TypeBigInt)
+		Expect(err).To(HaveOccurred())
+
+		_, err = convert.ToBytes((*big.Int)(nil))
+		Expect(err).To(MatchError(convert.ErrUnableToConvertNilBigInt))
+	})
+
+	It(`Decimal`, func() {
+		d, err := convert.FromBytes([]byte(`-12.5`), convert.Decimal{Scale: 2})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(d.(convert.Decimal).String()).To(Equal(`-12.50`))
+		Expect(d.(convert.Decimal).Cmp(convert.NewDecimal(-1250, 2))).To(Equal(0))
+		Expect(convert.NewDecimal(5, 3).String()).To(Equal(`0.005`))
+
+		_, err = convert.FromBytes([]byte(`1.234`), convert.Decimal{Scale: 2})
+		Expect(err).To(MatchError(ContainSubstring(convert.ErrDecimalScaleExceeded.Error())))
+
+		_, err = convert.ParseDecimal(`100`, -2)
+		Expect(err).To(MatchError(ContainSubstring(convert.ErrDecimalNegativeScale.Error())))
+	})
+
+	It(`Time`, func() {
+		t := time.Date(2019, 5, 1, 10, 20, 30, 500, time.UTC)
+		b, err := convert.ToBytes(t)
+		Expect(err).NotTo(HaveOccurred())
+
+		eTime, err := convert.FromBytes(b, convert.TypeTime)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(eTime.(time.Time).Equal(t)).To(BeTrue())
+	})
+
+	It(`String based type`, func() {
+		type OrderType string
+		e, err := convert.FromBytes([]byte(`buy`), OrderType(``))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e).To(Equal(OrderType(`buy`)))
+	})
+
 })
@@ -0,0 +1,101 @@
+package convert
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrInvalidDecimal occurs when string cannot be parsed as decimal number
+	ErrInvalidDecimal = errors.New(`invalid decimal`)
+	// ErrDecimalScaleExceeded occurs when decimal number has more fractional digits than allowed by scale
+	ErrDecimalScaleExceeded = errors.New(`decimal scale exceeded`)
+	// ErrDecimalNegativeScale occurs when decimal scale is less than zero
+	ErrDecimalNegativeScale = errors.New(`decimal scale must not be negative`)
+)
+
+// Decimal fixed-point decimal number, value equals Unscaled * 10^-Scale
+type Decimal struct {
+	Unscaled *big.Int
+	Scale    int
+}
+
+// NewDecimal creates decimal from unscaled value and scale
+func NewDecimal(unscaled int64, scale int) Decimal {
+	return Decimal{Unscaled: big.NewInt(unscaled), Scale: scale}
+}
+
+// ParseDecimal parses string like "-123.45" to decimal with provided scale,
+// string must not contain more fractional digits than scale, scale must not be negative
+func ParseDecimal(s string, scale int) (Decimal, error) {
+	if scale < 0 {
+		return Decimal{}, fmt.Errorf(`%s: %d`, ErrDecimalNegativeScale, scale)
+	}
+
+	str := strings.TrimSpace(s)
+	intPart, fracPart := str, ``
+	if pos := strings.IndexByte(str, '.'); pos >= 0 {
+		intPart, fracPart = str[:pos], str[pos+1:]
+	}
+
+	if len(fracPart) > scale {
+		return Decimal{}, fmt.Errorf(`%s: %s, scale %d`, ErrDecimalScaleExceeded, s, scale)
+	}
+
+	digits := intPart + fracPart + strings.Repeat(`0`, scale-len(fracPart))
+	unscaled, ok := new(big.Int).SetString(digits, 10)
+	if !ok || intPart == `` || intPart == `-` || intPart == `+` || strings.ContainsAny(fracPart, `+-`) {
+		return Decimal{}, fmt.Errorf(`%s: %s`, ErrInvalidDecimal, s)
+	}
+
+	return Decimal{Unscaled: unscaled, Scale: scale}, nil
+}
+
+// String returns decimal representation with exactly Scale fractional digits
+func (d Decimal) String() string {
+	if d.Unscaled == nil {
+		return Decimal{Unscaled: new(big.Int), Scale: d.Scale}.String()
+	}
+	if d.Scale <= 0 {
+		return d.Unscaled.String()
+	}
+
+	abs := new(big.Int).Abs(d.Unscaled).String()
+	if len(abs) <= d.Scale {
+		abs = strings.Repeat(`0`, d.Scale-len(abs)+1) + abs
+	}
+
+	sign := ``
+	if d.Unscaled.Sign() < 0 {
+		sign = `-`
+	}
+	return sign + abs[:len(abs)-d.Scale] + `.` + abs[len(abs)-d.Scale:]
+}
+
+// Rat returns decimal value as big.Rat
+func (d Decimal) Rat() *big.Rat {
+	unscaled := d.Unscaled
+	if unscaled == nil {
+		unscaled = new(big.Int)
+	}
+	denom := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.Scale)), nil)
+	return new(big.Rat).SetFrac(unscaled, denom)
+}
+
+// Cmp compares decimals, returns -1, 0 or +1
+func (d Decimal) Cmp(other Decimal) int {
+	return d.Rat().Cmp(other.Rat())
+}
+
+// ToBytes implements ToByter interface
+func (d Decimal) ToBytes() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// FromBytes implements FromByter interface, scale of target decimal is used for parsing
+func (d Decimal) FromBytes(bb []byte) (interface{}, error) {
+	return ParseDecimal(string(bb), d.Scale)
+}
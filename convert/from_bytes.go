@@ -3,8 +3,10 @@ package convert
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"reflect"
 	"strconv"
+	"time"
 
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/protos/peer"
@@ -25,6 +27,18 @@ func FromBytes(bb []byte, target interface{}) (result interface{}, err error) {
 		return bb, nil
 	case int:
 		return strconv.Atoi(string(bb))
+	case int64:
+		return strconv.ParseInt(string(bb), 10, 64)
+	case uint64:
+		return strconv.ParseUint(string(bb), 10, 64)
+	case *big.Int:
+		i, ok := new(big.Int).SetString(string(bb), 10)
+		if !ok {
+			return nil, fmt.Errorf(`%s: %s`, ErrUnableToConvertValueToBigInt, string(bb))
+		}
+		return i, nil
+	case time.Time:
+		return time.Parse(time.RFC3339Nano, string(bb))
 	case bool:
 		return strconv.ParseBool(string(bb))
 	case []string:
@@ -46,6 +60,12 @@ func FromBytes(bb []byte, target interface{}) (result interface{}, err error) {
 		return ProtoUnmarshal(bb, t)
 
 	default:
+		// string based types, i.e. enums
+		if reflect.TypeOf(target).Kind() == reflect.String {
+			v := reflect.New(reflect.TypeOf(target)).Elem()
+			v.SetString(string(bb))
+			return v.Interface(), nil
+		}
 		return FromBytesToStruct(bb, target)
 	}
 
@@ -3,8 +3,10 @@ package convert
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"reflect"
 	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -43,8 +45,15 @@ func ToBytes(value interface{}) ([]byte, error) {
 		return []byte(strconv.FormatBool(v)), nil
 	case string:
 		return []byte(v), nil
-	case uint, int, int32:
+	case uint, uint32, uint64, int, int32, int64:
 		return []byte(fmt.Sprint(v)), nil
+	case *big.Int:
+		if v == nil {
+			return nil, ErrUnableToConvertNilBigInt
+		}
+		return []byte(v.String()), nil
+	case time.Time:
+		return []byte(v.Format(time.RFC3339Nano)), nil
 	case []byte:
 		return v, nil
 
@@ -0,0 +1,190 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/protos/peer"
+)
+
+// CCError structured chaincode error with status code, machine-readable reason and key/value details
+type CCError struct {
+	Code    int32             `json:"code"`
+	Reason  string            `json:"reason,omitempty"`
+	Message string            `json:"message"`
+	Details map[string]string `json:"details,omitempty"`
+
+	cause error
+}
+
+// NewCCError creates structured chaincode error, reason is machine-readable error identifier
+func NewCCError(code int32, reason, message string) *CCError {
+	return &CCError{Code: code, Reason: reason, Message: message}
+}
+
+// WrapCCError creates structured chaincode error with code and reason from err
+func WrapCCError(err error, code int32, reason string) *CCError {
+	return &CCError{Code: code, Reason: reason, Message: err.Error(), cause: err}
+}
+
+func (e *CCError) Error() string {
+	if e.Message == `` {
+		return e.Reason
+	}
+	return e.Message
+}
+
+// Cause returns wrapped error, compatible with github.com/pkg/errors
+func (e *CCError) Cause() error {
+	return e.cause
+}
+
+// Unwrap returns wrapped error, compatible with errors.Is / errors.As
+func (e *CCError) Unwrap() error {
+	return e.cause
+}
+
+// Is reports whether target is CCError with the same reason (or with the same code and message if reason is empty)
+func (e *CCError) Is(target error) bool {
+	t, ok := target.(*CCError)
+	if !ok {
+		return false
+	}
+	if e.Reason != `` || t.Reason != `` {
+		return e.Reason == t.Reason
+	}
+	return e.Code == t.Code && e.Message == t.Message
+}
+
+// WithDetail returns copy of error with added detail
+func (e *CCError) WithDetail(key, value string) *CCError {
+	details := make(map[string]string, len(e.Details)+1)
+	for k, v := range e.Details {
+		details[k] = v
+	}
+	details[key] = value
+
+	return &CCError{Code: e.Code, Reason: e.Reason, Message: e.Message, Details: details, cause: e.cause}
+}
+
+// Errorf returns copy of error with message, supplemented with formatted string
+func (e *CCError) Errorf(format string, args ...interface{}) *CCError {
+	return &CCError{
+		Code:    e.Code,
+		Reason:  e.Reason,
+		Message: fmt.Sprintf(`%s: %s`, e.Error(), fmt.Sprintf(format, args...)),
+		Details: e.Details,
+		cause:   e.cause,
+	}
+}
+
+// Wrap returns copy of error with cause err, message is supplemented with err message
+func (e *CCError) Wrap(err error) *CCError {
+	return &CCError{
+		Code:    e.Code,
+		Reason:  e.Reason,
+		Message: fmt.Sprintf(`%s: %s`, e.Error(), err),
+		Details: e.Details,
+		cause:   err,
+	}
+}
+
+// Bytes returns json representation of error, used as error response payload
+func (e *CCError) Bytes() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// Response returns error response with error code as status and json representation of error as payload
+func (e *CCError) Response() peer.Response {
+	payload, _ := e.Bytes()
+	return peer.Response{Status: e.Code, Message: e.Error(), Payload: payload}
+}
+
+// Chain returns err and all errors wrapped by it, using Unwrap or Cause methods
+func Chain(err error) []error {
+	var chain []error
+	for err != nil {
+		chain = append(chain, err)
+
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		case interface{ Cause() error }:
+			err = e.Cause()
+		default:
+			err = nil
+		}
+	}
+	return chain
+}
+
+// AsCCError returns first CCError in err chain
+func AsCCError(err error) (*CCError, bool) {
+	for _, e := range Chain(err) {
+		if ccErr, ok := e.(*CCError); ok {
+			return ccErr, true
+		}
+	}
+	return nil, false
+}
+
+// Is reports whether any error in err chain (Unwrap or Cause) matches target
+func Is(err, target error) bool {
+	if err == nil || target == nil {
+		return err == target
+	}
+	if stderrors.Is(err, target) {
+		return true
+	}
+	for _, e := range Chain(err) {
+		if reflect.TypeOf(e).Comparable() && e == target {
+			return true
+		}
+	}
+	return false
+}
+
+// Code returns response status for err: code of CCError in err chain (Unwrap or Cause) or code from mappings
+func Code(err error, mappings map[error]int32) (code int32, reason string, ok bool) {
+	for _, e := range Chain(err) {
+		if ccErr, isCCErr := e.(*CCError); isCCErr && ccErr.Code != 0 {
+			return ccErr.Code, ccErr.Reason, true
+		}
+		if !reflect.TypeOf(e).Comparable() {
+			continue
+		}
+		if code, ok = mappings[e]; ok {
+			return code, e.Error(), true
+		}
+	}
+	return 0, ``, false
+}
+
+// ToCCError converts err to CCError with provided code
+func ToCCError(err error, code int32, reason string) *CCError {
+	ccErr := &CCError{Code: code, Reason: reason, Message: err.Error(), cause: err}
+	if e, ok := AsCCError(err); ok {
+		if ccErr.Reason == `` {
+			ccErr.Reason = e.Reason
+		}
+		ccErr.Details = e.Details
+	}
+	return ccErr
+}
+
+// FromResponse decodes structured error from error response payload,
+// returns nil if response status is not error
+func FromResponse(response peer.Response) error {
+	if response.Status < shim.ERRORTHRESHOLD {
+		return nil
+	}
+
+	ccErr := new(CCError)
+	if len(response.Payload) > 0 && json.Unmarshal(response.Payload, ccErr) == nil && ccErr.Code != 0 {
+		return ccErr
+	}
+	return NewCCError(response.Status, ``, response.Message)
+}
@@ -26,6 +26,7 @@ var (
 	ErrMethodNotFound  = errors.New(`chaincode method not found`)
 	ErrArgsNumMismatch = errors.New(`chaincode method args count mismatch`)
 	ErrHandlerError    = errors.New(`router handler error`)
+	ErrHandlerPanic    = errors.New(`router handler panic`)
 
 	// Identity errors
 	CertificateError = errors.New(`certificate error`)
@@ -58,6 +59,7 @@ var errMap = map[error]int32{
 	ErrMethodNotFound:  404,
 	ErrArgsNumMismatch: 400,
 	ErrHandlerError:    599,
+	ErrHandlerPanic:    500,
 
 	// Identity errors
 	CertificateError: 400,
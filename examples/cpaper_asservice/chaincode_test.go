@@ -17,6 +17,7 @@ import (
 	s "github.com/optherium/cckit/examples/cpaper_asservice/service"
 	"github.com/optherium/cckit/extensions/encryption"
 	"github.com/optherium/cckit/router"
+	"github.com/optherium/cckit/router/param/defparam"
 	testcc "github.com/optherium/cckit/testing"
 	expectcc "github.com/optherium/cckit/testing/expect"
 )
@@ -36,7 +37,7 @@ func TestCommercialPaper(t *testing.T) {
 var (
 	ccImpl, ccEncImpl *router.Chaincode
 	err               error
-	cc, ccEnc         *testcc.MockStub
+	cc, ccEnc, caller *testcc.MockStub
 
 	encKey       = make([]byte, 32)
 	ccEncWrapped *encryption.MockStub
@@ -72,6 +73,18 @@ var _ = Describe(`CommercialPaper`, func() {
 		// all queries/invokes arguments to cc will be encrypted
 		ccEncWrapped = encryption.NewMockStub(ccEnc, encKey)
 
+		// chaincode, calling commercial paper chaincode via generated chaincode client
+		caller = testcc.NewMockStub(`cpaper_caller`, router.NewChaincode(router.New(`caller`).
+			Query(`paperOwner`, func(c router.Context) (interface{}, error) {
+				paper, err := s.NewCPaperChaincodeClient(c.Stub(), ``, `cpaper_as_service`).
+					Get(c.Param().(*schema.CommercialPaperId))
+				if err != nil {
+					return nil, err
+				}
+				return paper.Owner, nil
+			}, defparam.Proto(&schema.CommercialPaperId{}))))
+		caller.MockPeerChaincode(`cpaper_as_service`, cc)
+
 		identity, err = testcc.IdentityFromFile(MspName, `./testdata/admin.pem`, ioutil.ReadFile)
 		Expect(err).NotTo(HaveOccurred())
 		// Init chaincode with admin identity
@@ -125,6 +138,18 @@ var _ = Describe(`CommercialPaper`, func() {
 			Expect(paper.FaceValue).To(BeNumerically("==", 100000))
 		})
 
+		It("Allow another chaincode to get commercial paper via chaincode client", func() {
+			expectcc.PayloadString(caller.Query(`paperOwner`, &schema.CommercialPaperId{
+				Issuer:      IssuerName,
+				PaperNumber: "0001",
+			}), IssuerName)
+
+			expectcc.ResponseError(caller.Query(`paperOwner`, &schema.CommercialPaperId{
+				Issuer:      IssuerName,
+				PaperNumber: "0002",
+			}), `state entry not found`)
+		})
+
 		It("Allow issuer to get a list of commercial papers", func() {
 			queryResponse := cc.Query(s.CPaperChaincode_List, &empty.Empty{})
 
@@ -5,6 +5,8 @@
 Package service contains
   *   chaincode interface definition
   *   chaincode gateway definition
+  *   chaincode client for calling from another chaincode
+  *   remote gateway client for calling gateway via gRPC
   *   chaincode service to cckit router registration func
 */
 package service
@@ -13,6 +15,7 @@ import (
 	context "context"
 
 	"github.com/golang/protobuf/ptypes/empty"
+	shim "github.com/hyperledger/fabric/core/chaincode/shim"
 	errors "github.com/pkg/errors"
 	"github.com/optherium/cckit/examples/cpaper_asservice/schema"
 	cckit_gateway "github.com/optherium/cckit/gateway"
@@ -20,6 +23,7 @@ import (
 	cckit_router "github.com/optherium/cckit/router"
 	cckit_param "github.com/optherium/cckit/router/param"
 	cckit_defparam "github.com/optherium/cckit/router/param/defparam"
+	grpc "google.golang.org/grpc"
 )
 
 type ValidatorInterface interface {
@@ -143,6 +147,26 @@ func RegisterCPaperChaincode(r *cckit_router.Group, cc CPaperChaincode) error {
 	return nil
 }
 
+// CPaperGatewayInterface is implemented by local chaincode gateway and remote gateway client
+type CPaperGatewayInterface interface {
+	ApiDef() cckit_gateway.ServiceDef
+	Events(ctx context.Context, opts ...cckit_gateway.EventsOpt) (cckit_gateway.ChaincodeEventSub, error)
+
+	List(ctx context.Context, in *empty.Empty) (*schema.CommercialPaperList, error)
+
+	Get(ctx context.Context, in *schema.CommercialPaperId) (*schema.CommercialPaper, error)
+
+	GetByExternalId(ctx context.Context, in *schema.ExternalId) (*schema.CommercialPaper, error)
+
+	Issue(ctx context.Context, in *schema.IssueCommercialPaper) (*schema.CommercialPaper, error)
+
+	Buy(ctx context.Context, in *schema.BuyCommercialPaper) (*schema.CommercialPaper, error)
+
+	Redeem(ctx context.Context, in *schema.RedeemCommercialPaper) (*schema.CommercialPaper, error)
+
+	Delete(ctx context.Context, in *schema.CommercialPaperId) (*schema.CommercialPaper, error)
+}
+
 // NewCPaperGateway creates gateway to access chaincode method via chaincode service
 func NewCPaperGateway(ccService cckit_ccservice.Chaincode, channel, chaincode string, opts ...cckit_gateway.Opt) *CPaperGateway {
 	return &CPaperGateway{Gateway: cckit_gateway.NewChaincode(ccService, channel, chaincode, opts...)}
@@ -163,9 +187,10 @@ func (c *CPaperGateway) ApiDef() cckit_gateway.ServiceDef {
 	}
 }
 
-// Events returns events subscription
-func (c *CPaperGateway) Events(ctx context.Context) (cckit_gateway.ChaincodeEventSub, error) {
-	return c.Gateway.Events(ctx)
+// Events returns events subscription, opts define events stream filters and position.
+// Subscription TypedEvents decodes payloads to types, registered with WithEventTypes or WithEventMappings
+func (c *CPaperGateway) Events(ctx context.Context, opts ...cckit_gateway.EventsOpt) (cckit_gateway.ChaincodeEventSub, error) {
+	return c.Gateway.Events(ctx, opts...)
 }
 
 func (c *CPaperGateway) List(ctx context.Context, in *empty.Empty) (*schema.CommercialPaperList, error) {
@@ -272,3 +297,262 @@ func (c *CPaperGateway) Delete(ctx context.Context, in *schema.CommercialPaperId
 		return res.(*schema.CommercialPaper), nil
 	}
 }
+
+// NewCPaperChaincodeClient creates client for calling chaincode methods from another chaincode
+func NewCPaperChaincodeClient(stub shim.ChaincodeStubInterface, channel, chaincode string, opts ...cckit_gateway.Opt) *CPaperChaincodeClient {
+	return &CPaperChaincodeClient{Stub: stub, Client: cckit_gateway.NewChaincodeClient(channel, chaincode, opts...)}
+}
+
+// CPaperChaincodeClient calls chaincode methods via stub.InvokeChaincode
+type CPaperChaincodeClient struct {
+	Stub   shim.ChaincodeStubInterface
+	Client cckit_gateway.ChaincodeInvokeClient
+}
+
+func (c *CPaperChaincodeClient) List(in *empty.Empty) (*schema.CommercialPaperList, error) {
+	var inMsg interface{} = in
+	if v, ok := inMsg.(ValidatorInterface); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if res, err := c.Client.Query(c.Stub, CPaperChaincode_List, []interface{}{in}, &schema.CommercialPaperList{}); err != nil {
+		return nil, err
+	} else {
+		return res.(*schema.CommercialPaperList), nil
+	}
+}
+
+func (c *CPaperChaincodeClient) Get(in *schema.CommercialPaperId) (*schema.CommercialPaper, error) {
+	var inMsg interface{} = in
+	if v, ok := inMsg.(ValidatorInterface); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if res, err := c.Client.Query(c.Stub, CPaperChaincode_Get, []interface{}{in}, &schema.CommercialPaper{}); err != nil {
+		return nil, err
+	} else {
+		return res.(*schema.CommercialPaper), nil
+	}
+}
+
+func (c *CPaperChaincodeClient) GetByExternalId(in *schema.ExternalId) (*schema.CommercialPaper, error) {
+	var inMsg interface{} = in
+	if v, ok := inMsg.(ValidatorInterface); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if res, err := c.Client.Query(c.Stub, CPaperChaincode_GetByExternalId, []interface{}{in}, &schema.CommercialPaper{}); err != nil {
+		return nil, err
+	} else {
+		return res.(*schema.CommercialPaper), nil
+	}
+}
+
+func (c *CPaperChaincodeClient) Issue(in *schema.IssueCommercialPaper) (*schema.CommercialPaper, error) {
+	var inMsg interface{} = in
+	if v, ok := inMsg.(ValidatorInterface); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if res, err := c.Client.Invoke(c.Stub, CPaperChaincode_Issue, []interface{}{in}, &schema.CommercialPaper{}); err != nil {
+		return nil, err
+	} else {
+		return res.(*schema.CommercialPaper), nil
+	}
+}
+
+func (c *CPaperChaincodeClient) Buy(in *schema.BuyCommercialPaper) (*schema.CommercialPaper, error) {
+	var inMsg interface{} = in
+	if v, ok := inMsg.(ValidatorInterface); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if res, err := c.Client.Invoke(c.Stub, CPaperChaincode_Buy, []interface{}{in}, &schema.CommercialPaper{}); err != nil {
+		return nil, err
+	} else {
+		return res.(*schema.CommercialPaper), nil
+	}
+}
+
+func (c *CPaperChaincodeClient) Redeem(in *schema.RedeemCommercialPaper) (*schema.CommercialPaper, error) {
+	var inMsg interface{} = in
+	if v, ok := inMsg.(ValidatorInterface); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if res, err := c.Client.Invoke(c.Stub, CPaperChaincode_Redeem, []interface{}{in}, &schema.CommercialPaper{}); err != nil {
+		return nil, err
+	} else {
+		return res.(*schema.CommercialPaper), nil
+	}
+}
+
+func (c *CPaperChaincodeClient) Delete(in *schema.CommercialPaperId) (*schema.CommercialPaper, error) {
+	var inMsg interface{} = in
+	if v, ok := inMsg.(ValidatorInterface); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if res, err := c.Client.Invoke(c.Stub, CPaperChaincode_Delete, []interface{}{in}, &schema.CommercialPaper{}); err != nil {
+		return nil, err
+	} else {
+		return res.(*schema.CommercialPaper), nil
+	}
+}
+
+// NewCPaperGatewayClient creates client to remote chaincode gateway, exposed via gRPC (i.e. by gateway server),
+// opts define events processing (i.e. WithEventDecryption, WithEventTypes)
+func NewCPaperGatewayClient(conn *grpc.ClientConn, channel, chaincode string, opts ...cckit_gateway.Opt) *CPaperGatewayClient {
+	return &CPaperGatewayClient{
+		Client:       NewCPaperClient(conn),
+		RemoteEvents: cckit_gateway.NewRemoteEvents(
+			cckit_ccservice.NewChaincodeClient(conn), channel, chaincode, cckit_gateway.NewEventsConfig(opts...)),
+	}
+}
+
+// CPaperGatewayClient calls chaincode methods of remote gateway via gRPC, has the same method set as gateway
+type CPaperGatewayClient struct {
+	Client       CPaperClient
+	RemoteEvents *cckit_gateway.RemoteEvents
+}
+
+// ApiDef returns service definition, gateway client can be exposed as proxy to remote gateway
+func (c *CPaperGatewayClient) ApiDef() cckit_gateway.ServiceDef {
+	return cckit_gateway.ServiceDef{
+		Desc:                        &_CPaper_serviceDesc,
+		Service:                     c,
+		HandlerFromEndpointRegister: RegisterCPaperHandlerFromEndpoint,
+	}
+}
+
+// Events returns events subscription, opts define events stream filters and position.
+// Subscription TypedEvents decodes payloads to types, registered with WithEventTypes or WithEventMappings
+func (c *CPaperGatewayClient) Events(ctx context.Context, opts ...cckit_gateway.EventsOpt) (cckit_gateway.ChaincodeEventSub, error) {
+	return c.RemoteEvents.Events(ctx, opts...)
+}
+
+func (c *CPaperGatewayClient) List(ctx context.Context, in *empty.Empty) (*schema.CommercialPaperList, error) {
+	var inMsg interface{} = in
+	if v, ok := inMsg.(ValidatorInterface); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if res, err := c.Client.List(ctx, in); err != nil {
+		// decode chaincode error from gRPC status details
+		return nil, cckit_ccservice.FromStatus(err)
+	} else {
+		return res, nil
+	}
+}
+
+func (c *CPaperGatewayClient) Get(ctx context.Context, in *schema.CommercialPaperId) (*schema.CommercialPaper, error) {
+	var inMsg interface{} = in
+	if v, ok := inMsg.(ValidatorInterface); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if res, err := c.Client.Get(ctx, in); err != nil {
+		// decode chaincode error from gRPC status details
+		return nil, cckit_ccservice.FromStatus(err)
+	} else {
+		return res, nil
+	}
+}
+
+func (c *CPaperGatewayClient) GetByExternalId(ctx context.Context, in *schema.ExternalId) (*schema.CommercialPaper, error) {
+	var inMsg interface{} = in
+	if v, ok := inMsg.(ValidatorInterface); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if res, err := c.Client.GetByExternalId(ctx, in); err != nil {
+		// decode chaincode error from gRPC status details
+		return nil, cckit_ccservice.FromStatus(err)
+	} else {
+		return res, nil
+	}
+}
+
+func (c *CPaperGatewayClient) Issue(ctx context.Context, in *schema.IssueCommercialPaper) (*schema.CommercialPaper, error) {
+	var inMsg interface{} = in
+	if v, ok := inMsg.(ValidatorInterface); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if res, err := c.Client.Issue(ctx, in); err != nil {
+		// decode chaincode error from gRPC status details
+		return nil, cckit_ccservice.FromStatus(err)
+	} else {
+		return res, nil
+	}
+}
+
+func (c *CPaperGatewayClient) Buy(ctx context.Context, in *schema.BuyCommercialPaper) (*schema.CommercialPaper, error) {
+	var inMsg interface{} = in
+	if v, ok := inMsg.(ValidatorInterface); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if res, err := c.Client.Buy(ctx, in); err != nil {
+		// decode chaincode error from gRPC status details
+		return nil, cckit_ccservice.FromStatus(err)
+	} else {
+		return res, nil
+	}
+}
+
+func (c *CPaperGatewayClient) Redeem(ctx context.Context, in *schema.RedeemCommercialPaper) (*schema.CommercialPaper, error) {
+	var inMsg interface{} = in
+	if v, ok := inMsg.(ValidatorInterface); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if res, err := c.Client.Redeem(ctx, in); err != nil {
+		// decode chaincode error from gRPC status details
+		return nil, cckit_ccservice.FromStatus(err)
+	} else {
+		return res, nil
+	}
+}
+
+func (c *CPaperGatewayClient) Delete(ctx context.Context, in *schema.CommercialPaperId) (*schema.CommercialPaper, error) {
+	var inMsg interface{} = in
+	if v, ok := inMsg.(ValidatorInterface); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if res, err := c.Client.Delete(ctx, in); err != nil {
+		// decode chaincode error from gRPC status details
+		return nil, cckit_ccservice.FromStatus(err)
+	} else {
+		return res, nil
+	}
+}
@@ -0,0 +1,124 @@
+// Package abac provides attribute-based access control, using attributes
+// added to X.509 certificate by Fabric CA
+package abac
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/attrmgr"
+	"github.com/pkg/errors"
+
+	"github.com/optherium/cckit/router"
+)
+
+const contextAttrsKey = `_abacAttrs`
+
+var (
+	// ErrAttributeNotFound occurs when attribute not exists in invoker certificate
+	ErrAttributeNotFound = errors.New(`attribute not found`)
+)
+
+// Attributes of tx creator certificate with typed accessors
+type Attributes map[string]string
+
+// FromContext returns attributes of tx creator certificate, attributes are cached in context
+func FromContext(c router.Context) (Attributes, error) {
+	if attrs, ok := c.Get(contextAttrsKey).(Attributes); ok {
+		return attrs, nil
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		return nil, errors.Wrap(err, `client identity`)
+	}
+	cert, err := client.GetX509Certificate()
+	if err != nil {
+		return nil, errors.Wrap(err, `client certificate`)
+	}
+
+	certAttrs, err := attrmgr.New().GetAttributesFromCert(cert)
+	if err != nil {
+		return nil, errors.Wrap(err, `certificate attributes`)
+	}
+
+	attrs := make(Attributes)
+	for name, value := range certAttrs.Attrs {
+		attrs[name] = value
+	}
+	c.Set(contextAttrsKey, attrs)
+	return attrs, nil
+}
+
+// Has checks attribute exists
+func (a Attributes) Has(name string) bool {
+	_, ok := a[name]
+	return ok
+}
+
+// String returns attribute value
+func (a Attributes) String(name string) (string, error) {
+	value, ok := a[name]
+	if !ok {
+		return ``, errors.Errorf(`%s: %s`, ErrAttributeNotFound, name)
+	}
+	return value, nil
+}
+
+// Strings returns comma separated attribute value as slice of strings
+func (a Attributes) Strings(name string) ([]string, error) {
+	value, err := a.String(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	for _, v := range strings.Split(value, `,`) {
+		if v = strings.TrimSpace(v); v != `` {
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+// Bool returns attribute value as bool
+func (a Attributes) Bool(name string) (bool, error) {
+	value, err := a.String(name)
+	if err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(value)
+}
+
+// Int returns attribute value as int
+func (a Attributes) Int(name string) (int, error) {
+	value, err := a.String(name)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(value)
+}
+
+// Float returns attribute value as float64
+func (a Attributes) Float(name string) (float64, error) {
+	value, err := a.String(name)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+// Typed returns attributes with values converted to bool or float64 if possible, used in expressions
+func (a Attributes) Typed() map[string]interface{} {
+	typed := make(map[string]interface{}, len(a))
+	for name, value := range a {
+		if value == `true` || value == `false` {
+			typed[name] = value == `true`
+		} else if f, err := strconv.ParseFloat(value, 64); err == nil {
+			typed[name] = f
+		} else {
+			typed[name] = value
+		}
+	}
+	return typed
+}
@@ -0,0 +1,65 @@
+package abac_test
+
+import (
+	"crypto/x509/pkix"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/optherium/cckit/extensions/abac"
+	"github.com/optherium/cckit/router"
+	testcc "github.com/optherium/cckit/testing"
+	expectcc "github.com/optherium/cckit/testing/expect"
+)
+
+func TestABAC(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ABAC suite")
+}
+
+func New() *router.Chaincode {
+	r := router.New(`abac`).
+		Init(router.EmptyContextHandler).
+		Query(`audit`, router.EmptyContextHandler, abac.Require(`role`, `auditor`, `admin`)).
+		Query(`approve`, router.EmptyContextHandler, abac.Expr(`role == "manager" && level >= 3`)).
+		Query(`level`, func(c router.Context) (interface{}, error) {
+			attrs, err := abac.FromContext(c)
+			if err != nil {
+				return nil, err
+			}
+			return attrs.Int(`level`)
+		})
+
+	return router.NewChaincode(r)
+}
+
+var _ = Describe(`ABAC`, func() {
+
+	cc := testcc.NewMockStub(`abac`, New())
+
+	auditor := testcc.MustGenerateIdentity(`SOME_MSP`, pkix.Name{CommonName: `auditor`},
+		map[string]string{`role`: `auditor`})
+	manager := testcc.MustGenerateIdentity(`SOME_MSP`, pkix.Name{CommonName: `manager`},
+		map[string]string{`role`: `manager`, `level`: `3`})
+	juniorManager := testcc.MustGenerateIdentity(`SOME_MSP`, pkix.Name{CommonName: `junior`},
+		map[string]string{`role`: `manager`, `level`: `1`})
+	noAttrs := testcc.MustGenerateIdentity(`SOME_MSP`, pkix.Name{CommonName: `someone`}, nil)
+
+	It(`Allow access with required attribute`, func() {
+		expectcc.ResponseOk(cc.From(auditor).Query(`audit`))
+		expectcc.ResponseErrorIs(cc.From(manager).Query(`audit`), abac.ErrAttributeRequired)
+		expectcc.ResponseErrorIs(cc.From(noAttrs).Query(`audit`), abac.ErrAttributeRequired)
+	})
+
+	It(`Allow access with attributes satisfying expression`, func() {
+		expectcc.ResponseOk(cc.From(manager).Query(`approve`))
+		expectcc.ResponseErrorIs(cc.From(juniorManager).Query(`approve`), abac.ErrAttributeRequired)
+		expectcc.ResponseErrorIs(cc.From(auditor).Query(`approve`), abac.ErrAttributeRequired)
+	})
+
+	It(`Allow to get typed attributes in handler`, func() {
+		expectcc.PayloadInt(cc.From(manager).Query(`level`), 3)
+		expectcc.ResponseError(cc.From(auditor).Query(`level`), abac.ErrAttributeNotFound)
+	})
+})
@@ -0,0 +1,77 @@
+package abac
+
+import (
+	"fmt"
+
+	"github.com/Knetic/govaluate"
+
+	"github.com/optherium/cckit/errors"
+	"github.com/optherium/cckit/router"
+)
+
+var (
+	// ErrAttributeRequired occurs when tx creator certificate attributes don't satisfy access requirements
+	ErrAttributeRequired = errors.NewCCError(403, `ATTRIBUTE_REQUIRED`, `attribute required`)
+)
+
+// Require allows access if tx creator certificate has attribute with one of values
+func Require(name string, values ...string) router.MiddlewareFunc {
+	return check(fmt.Sprintf(`%s in %v`, name, values), func(attrs Attributes) (bool, error) {
+		value, ok := attrs[name]
+		if !ok {
+			return false, nil
+		}
+		for _, v := range values {
+			if v == value {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+}
+
+// Expr allows access if tx creator certificate attributes satisfy boolean expression,
+// i.e. `role == "auditor" && level >= 3`, absent attributes cause denial. Panics if expression is invalid
+func Expr(expression string) router.MiddlewareFunc {
+	expr, err := govaluate.NewEvaluableExpression(expression)
+	if err != nil {
+		panic(fmt.Sprintf(`abac expression %s: %s`, expression, err))
+	}
+
+	return check(expression, func(attrs Attributes) (bool, error) {
+		typed := attrs.Typed()
+		for _, v := range expr.Vars() {
+			if _, ok := typed[v]; !ok {
+				return false, nil
+			}
+		}
+
+		res, err := expr.Evaluate(typed)
+		if err != nil {
+			return false, err
+		}
+		allowed, _ := res.(bool)
+		return allowed, nil
+	})
+}
+
+func check(requirement string, allow func(Attributes) (bool, error)) router.MiddlewareFunc {
+	return func(next router.HandlerFunc, pos ...int) router.HandlerFunc {
+		return func(c router.Context) (interface{}, error) {
+			attrs, err := FromContext(c)
+			if err != nil {
+				return nil, ErrAttributeRequired.WithDetail(`requirement`, requirement).WithDetail(`error`, err.Error())
+			}
+
+			allowed, err := allow(attrs)
+			if err != nil || !allowed {
+				denied := ErrAttributeRequired.WithDetail(`requirement`, requirement)
+				if err != nil {
+					denied = denied.WithDetail(`error`, err.Error())
+				}
+				return nil, denied
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Package acl provides declarative access control middleware for router methods
+package acl
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/optherium/cckit/errors"
+	"github.com/optherium/cckit/identity"
+	"github.com/optherium/cckit/router"
+)
+
+// RolesStateKey key prefix used to store identity roles in chaincode state
+const RolesStateKey = `ACL_ROLES`
+
+var (
+	// ErrAccessDenied occurs when invoker is not allowed to call chaincode method
+	ErrAccessDenied = errors.NewCCError(403, `ACCESS_DENIED`, `access denied`)
+
+	// ErrRuleNotDefined occurs when rule without check, i.e. zero value or decoded from json, is evaluated
+	ErrRuleNotDefined = errors.NewCCError(500, `ACL_RULE_NOT_DEFINED`, `acl rule not defined`)
+)
+
+// PathSeparators separate group prefix from method name in path, in addition to upper case letter
+// (router groups compose paths as prefix + Name, i.e. carRegister)
+const PathSeparators = `/.:_-`
+
+type (
+	// ACL access control rules for chaincode methods, declared per path or per path prefix (group)
+	ACL struct {
+		paths       map[string]Rule
+		prefixes    map[string]Rule
+		defaultRule Rule
+	}
+
+	// PathRule access rule for chaincode method path or path prefix
+	PathRule struct {
+		Path   string `json:"path"`
+		Prefix bool   `json:"prefix,omitempty"`
+		Rule   Rule   `json:"rule"`
+	}
+)
+
+// New creates ACL, by default access to methods without rules is denied, use Default to change it
+func New() *ACL {
+	return &ACL{
+		paths:       make(map[string]Rule),
+		prefixes:    make(map[string]Rule),
+		defaultRule: Deny(),
+	}
+}
+
+// Path sets rule for chaincode method path, multiple rules are composed with And
+func (a *ACL) Path(path string, rules ...Rule) *ACL {
+	a.paths[path] = compose(rules)
+	return a
+}
+
+// Group sets rule for all chaincode methods with path prefix, followed by path separator or upper case letter
+// (i.e. group `car` matches `carRegister` and `car.register`, but not `cartel`), multiple rules are composed with And
+func (a *ACL) Group(prefix string, rules ...Rule) *ACL {
+	a.prefixes[prefix] = compose(rules)
+	return a
+}
+
+// Default sets rule for chaincode methods without path or group rules
+func (a *ACL) Default(rules ...Rule) *ACL {
+	a.defaultRule = compose(rules)
+	return a
+}
+
+// Rule returns rule for chaincode method path: path rule, longest prefix rule or default rule
+func (a *ACL) Rule(path string) Rule {
+	if rule, ok := a.paths[path]; ok {
+		return rule
+	}
+
+	var (
+		matched string
+		rule    = a.defaultRule
+	)
+	for prefix, prefixRule := range a.prefixes {
+		if hasPathPrefix(path, prefix) && len(prefix) >= len(matched) {
+			matched, rule = prefix, prefixRule
+		}
+	}
+	return rule
+}
+
+// Rules returns all declared path and group rules
+func (a *ACL) Rules() []PathRule {
+	var rules []PathRule
+	for path, rule := range a.paths {
+		rules = append(rules, PathRule{Path: path, Rule: rule})
+	}
+	for prefix, rule := range a.prefixes {
+		rules = append(rules, PathRule{Path: prefix, Prefix: true, Rule: rule})
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Path < rules[j].Path
+	})
+	return rules
+}
+
+// Allowed checks tx creator is allowed to call chaincode method
+func (a *ACL) Allowed(c router.Context, path string) (bool, error) {
+	invoker, err := identity.FromStub(c.Stub())
+	if err != nil {
+		return false, err
+	}
+	return a.Rule(path).Check(c, invoker)
+}
+
+// Middleware checks access to chaincode method, denials return ErrAccessDenied with path in details
+func (a *ACL) Middleware() router.MiddlewareFunc {
+	return func(next router.HandlerFunc, pos ...int) router.HandlerFunc {
+		return func(c router.Context) (interface{}, error) {
+			allowed, err := a.Allowed(c, c.Path())
+			if !allowed || err != nil {
+				denied := ErrAccessDenied.WithDetail(`path`, c.Path())
+				if err != nil {
+					denied = denied.WithDetail(`error`, err.Error())
+				}
+				return nil, denied
+			}
+			return next(c)
+		}
+	}
+}
+
+// GetRoles returns identity roles from chaincode state
+func GetRoles(c router.Context, id identity.Id) ([]string, error) {
+	key := []string{RolesStateKey, id.MSP, id.Cert}
+	exists, err := c.State().Exists(key)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	roles, err := c.State().Get(key, []string{})
+	if err != nil {
+		return nil, err
+	}
+	return roles.([]string), nil
+}
+
+// SetRoles puts identity roles to chaincode state, empty roles are deleted from state
+func SetRoles(c router.Context, id identity.Id, roles ...string) error {
+	key := []string{RolesStateKey, id.MSP, id.Cert}
+	if len(roles) == 0 {
+		return c.State().Delete(key)
+	}
+	return c.State().Put(key, roles)
+}
+
+// hasPathPrefix reports whether path starts with prefix, ending on path separator boundary
+func hasPathPrefix(path, prefix string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+	if len(path) == len(prefix) || prefix == `` || strings.ContainsAny(prefix[len(prefix)-1:], PathSeparators) {
+		return true
+	}
+	next, _ := utf8.DecodeRuneInString(path[len(prefix):])
+	return unicode.IsUpper(next) || strings.ContainsRune(PathSeparators, next)
+}
+
+func compose(rules []Rule) Rule {
+	if len(rules) == 1 {
+		return rules[0]
+	}
+	return And(rules...)
+}
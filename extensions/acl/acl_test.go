@@ -0,0 +1,120 @@
+package acl_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/optherium/cckit/errors"
+	"github.com/optherium/cckit/examples/cert"
+	"github.com/optherium/cckit/extensions/acl"
+	"github.com/optherium/cckit/extensions/owner"
+	"github.com/optherium/cckit/identity"
+	"github.com/optherium/cckit/router"
+	p "github.com/optherium/cckit/router/param"
+	testcc "github.com/optherium/cckit/testing"
+	expectcc "github.com/optherium/cckit/testing/expect"
+)
+
+func TestACL(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ACL suite")
+}
+
+func New() *router.Chaincode {
+	r := router.New(`acl`)
+	a := acl.New().
+		Path(`blockchainDept`, acl.OU(`Blockchain dept`)).
+		Path(`ownerOrS7`, acl.Or(acl.Owner(), acl.Subject(`CN=Some Person`))).
+		Path(`someOrgAuditor`, acl.MSP(`SOME_MSP`), acl.Role(`auditor`)).
+		Group(`admin`, acl.Owner()).
+		Path(`init`, acl.Any()).
+		Path(acl.QueryRulesMethod, acl.Any()).
+		Path(acl.QueryAllowedMethod, acl.Any())
+
+	r.Use(a.Middleware()).
+		Init(owner.InvokeSetFromCreator).
+		Invoke(`blockchainDept`, router.EmptyContextHandler).
+		Invoke(`ownerOrS7`, router.EmptyContextHandler).
+		Invoke(`someOrgAuditor`, router.EmptyContextHandler).
+		Invoke(`notDeclared`, router.EmptyContextHandler).
+		Invoke(`adminSetRoles`, func(c router.Context) (interface{}, error) {
+			return nil, acl.SetRoles(c, c.ParamIdentity(`id`), c.ParamString(`role`))
+		}, p.Identity(`id`), p.String(`role`)).
+		Query(acl.QueryRulesMethod, a.QueryRules).
+		Query(acl.QueryAllowedMethod, a.QueryAllowed(r))
+
+	return router.NewChaincode(r)
+}
+
+var _ = Describe(`ACL`, func() {
+
+	cc := testcc.NewMockStub(`acl`, New())
+	actors := testcc.MustIdentitiesFromFiles(`SOME_MSP`, map[string]string{
+		`owner`:   `s7techlab.pem`,
+		`victor`:  `victor-nosov.pem`,
+		`someone`: `some-person.pem`}, cert.Content)
+
+	BeforeSuite(func() {
+		expectcc.ResponseOk(cc.From(actors[`owner`]).Init())
+	})
+
+	It(`Allow access by OU`, func() {
+		expectcc.ResponseOk(cc.From(actors[`victor`]).Invoke(`blockchainDept`))
+		expectcc.ResponseErrorIs(cc.From(actors[`owner`]).Invoke(`blockchainDept`), acl.ErrAccessDenied)
+	})
+
+	It(`Allow access by composed rules`, func() {
+		expectcc.ResponseOk(cc.From(actors[`owner`]).Invoke(`ownerOrS7`))
+		expectcc.ResponseOk(cc.From(actors[`someone`]).Invoke(`ownerOrS7`))
+		denied := expectcc.ResponseErrorIs(cc.From(actors[`victor`]).Invoke(`ownerOrS7`), acl.ErrAccessDenied)
+		Expect(denied.Code).To(BeEquivalentTo(403))
+		Expect(denied.Details[`path`]).To(Equal(`ownerOrS7`))
+	})
+
+	It(`Allow access by role in state`, func() {
+		expectcc.ResponseErrorIs(cc.From(actors[`someone`]).Invoke(`someOrgAuditor`), acl.ErrAccessDenied)
+
+		someone := identity.Id{MSP: `SOME_MSP`, Cert: actors[`someone`].GetID()}
+		expectcc.ResponseErrorIs(cc.From(actors[`someone`]).Invoke(`adminSetRoles`, someone, `auditor`), acl.ErrAccessDenied)
+		expectcc.ResponseOk(cc.From(actors[`owner`]).Invoke(`adminSetRoles`, someone, `auditor`))
+
+		expectcc.ResponseOk(cc.From(actors[`someone`]).Invoke(`someOrgAuditor`))
+	})
+
+	It(`Allow to query rules and allowed methods`, func() {
+		rules := expectcc.PayloadIs(cc.From(actors[`someone`]).Query(acl.QueryRulesMethod), &[]acl.PathRule{}).([]acl.PathRule)
+		Expect(rules).To(HaveLen(7))
+		Expect(rules[2].Path).To(Equal(`admin`))
+		Expect(rules[2].Prefix).To(BeTrue())
+
+		Expect(expectcc.PayloadIs(cc.From(actors[`victor`]).Query(acl.QueryAllowedMethod), &[]string{})).To(
+			Equal([]string{acl.QueryAllowedMethod, acl.QueryRulesMethod, `blockchainDept`, `init`}))
+	})
+
+	It(`Deny access to methods without rules by default`, func() {
+		expectcc.ResponseErrorIs(cc.From(actors[`owner`]).Invoke(`notDeclared`), acl.ErrAccessDenied)
+
+		Expect(acl.New().Rule(`notDeclared`).Name).To(Equal(acl.RuleDeny))
+		Expect(acl.New().Default(acl.Any()).Rule(`notDeclared`).Name).To(Equal(acl.RuleAny))
+	})
+
+	It(`Allow to match group prefix only on path separator boundary`, func() {
+		a := acl.New().Group(`admin`, acl.Any())
+		Expect(a.Rule(`adminSetRoles`).Name).To(Equal(acl.RuleAny))
+		Expect(a.Rule(`admin.setRoles`).Name).To(Equal(acl.RuleAny))
+		Expect(a.Rule(`admin`).Name).To(Equal(acl.RuleAny))
+		Expect(a.Rule(`administrator`).Name).To(Equal(acl.RuleDeny))
+	})
+
+	It(`Deny access by empty And and not defined rule`, func() {
+		allowed, err := acl.And().Check(nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allowed).To(BeFalse())
+
+		allowed, err = acl.Rule{Name: acl.RuleAny}.Check(nil, nil)
+		Expect(errors.Is(err, acl.ErrRuleNotDefined)).To(BeTrue())
+		Expect(allowed).To(BeFalse())
+	})
+})
@@ -0,0 +1,31 @@
+package acl
+
+import (
+	"sort"
+
+	"github.com/optherium/cckit/router"
+)
+
+const (
+	QueryRulesMethod   = `aclRules`
+	QueryAllowedMethod = `aclAllowed`
+)
+
+// QueryRules returns all declared access rules
+func (a *ACL) QueryRules(c router.Context) (interface{}, error) {
+	return a.Rules(), nil
+}
+
+// QueryAllowed returns chaincode method paths, registered in router, which tx creator is allowed to call
+func (a *ACL) QueryAllowed(r *router.Group) router.HandlerFunc {
+	return func(c router.Context) (interface{}, error) {
+		allowed := make([]string, 0)
+		for path := range r.Handlers() {
+			if ok, err := a.Allowed(c, path); ok && err == nil {
+				allowed = append(allowed, path)
+			}
+		}
+		sort.Strings(allowed)
+		return allowed, nil
+	}
+}
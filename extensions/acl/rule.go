@@ -0,0 +1,153 @@
+package acl
+
+import (
+	"regexp"
+
+	"github.com/optherium/cckit/extensions/owner"
+	"github.com/optherium/cckit/identity"
+	"github.com/optherium/cckit/router"
+)
+
+const (
+	RuleMSP     = `msp`
+	RuleOU      = `ou`
+	RuleSubject = `subject`
+	RuleOwner   = `owner`
+	RuleRole    = `role`
+	RuleAnd     = `and`
+	RuleOr      = `or`
+	RuleAny     = `any`
+	RuleDeny    = `deny`
+)
+
+type (
+	// Checker checks invoker is allowed to call chaincode method
+	Checker func(c router.Context, invoker *identity.CertIdentity) (bool, error)
+
+	// Rule declarative access control rule, can be composed with And / Or
+	Rule struct {
+		Name  string   `json:"name"`
+		Args  []string `json:"args,omitempty"`
+		Rules []Rule   `json:"rules,omitempty"`
+
+		check Checker
+	}
+)
+
+// Check evaluates rule for invoker, rule without check (i.e. decoded from json) returns ErrRuleNotDefined
+func (r Rule) Check(c router.Context, invoker *identity.CertIdentity) (bool, error) {
+	if r.check == nil {
+		return false, ErrRuleNotDefined.WithDetail(`rule`, r.Name)
+	}
+	return r.check(c, invoker)
+}
+
+// NewRule creates custom rule
+func NewRule(name string, check Checker, args ...string) Rule {
+	return Rule{Name: name, Args: args, check: check}
+}
+
+// Any allows access to anyone
+func Any() Rule {
+	return NewRule(RuleAny, func(router.Context, *identity.CertIdentity) (bool, error) {
+		return true, nil
+	})
+}
+
+// Deny denies access to anyone
+func Deny() Rule {
+	return NewRule(RuleDeny, func(router.Context, *identity.CertIdentity) (bool, error) {
+		return false, nil
+	})
+}
+
+// MSP allows access to invokers from one of membership service providers
+func MSP(mspIDs ...string) Rule {
+	return NewRule(RuleMSP, func(c router.Context, invoker *identity.CertIdentity) (bool, error) {
+		return contains(mspIDs, invoker.GetMSPID()), nil
+	}, mspIDs...)
+}
+
+// OU allows access to invokers with one of organizational units in certificate subject
+func OU(ous ...string) Rule {
+	return NewRule(RuleOU, func(c router.Context, invoker *identity.CertIdentity) (bool, error) {
+		for _, ou := range invoker.Cert.Subject.OrganizationalUnit {
+			if contains(ous, ou) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}, ous...)
+}
+
+// Subject allows access to invokers with certificate subject matching pattern, panics if pattern is invalid
+func Subject(pattern string) Rule {
+	re := regexp.MustCompile(pattern)
+	return NewRule(RuleSubject, func(c router.Context, invoker *identity.CertIdentity) (bool, error) {
+		return re.MatchString(invoker.GetSubject()), nil
+	}, pattern)
+}
+
+// Owner allows access to chaincode owner
+func Owner() Rule {
+	return NewRule(RuleOwner, func(c router.Context, invoker *identity.CertIdentity) (bool, error) {
+		return owner.IsInvoker(c)
+	})
+}
+
+// Role allows access to invokers, having one of roles in chaincode state
+func Role(roles ...string) Rule {
+	return NewRule(RuleRole, func(c router.Context, invoker *identity.CertIdentity) (bool, error) {
+		invokerRoles, err := GetRoles(c, identity.Id{MSP: invoker.GetMSPID(), Cert: invoker.GetID()})
+		if err != nil {
+			return false, err
+		}
+		for _, role := range invokerRoles {
+			if contains(roles, role) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}, roles...)
+}
+
+// And allows access if all rules allow access, And without rules denies access
+func And(rules ...Rule) Rule {
+	return Rule{Name: RuleAnd, Rules: rules, check: func(c router.Context, invoker *identity.CertIdentity) (bool, error) {
+		if len(rules) == 0 {
+			return false, nil
+		}
+		for _, rule := range rules {
+			if allowed, err := rule.Check(c, invoker); !allowed || err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	}}
+}
+
+// Or allows access if any of rules allows access, rule check errors are returned only if no rule allows access
+func Or(rules ...Rule) Rule {
+	return Rule{Name: RuleOr, Rules: rules, check: func(c router.Context, invoker *identity.CertIdentity) (bool, error) {
+		var lastErr error
+		for _, rule := range rules {
+			allowed, err := rule.Check(c, invoker)
+			if allowed && err == nil {
+				return true, nil
+			}
+			if err != nil {
+				lastErr = err
+			}
+		}
+		return false, lastErr
+	}}
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
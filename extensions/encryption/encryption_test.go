@@ -15,6 +15,8 @@ import (
 	"github.com/optherium/cckit/examples/payment/schema"
 	"github.com/optherium/cckit/extensions/encryption"
 	"github.com/optherium/cckit/extensions/encryption/testdata"
+	"github.com/optherium/cckit/router"
+	"github.com/optherium/cckit/router/param"
 	"github.com/optherium/cckit/state/mapping"
 	testcc "github.com/optherium/cckit/testing"
 	expectcc "github.com/optherium/cckit/testing/expect"
@@ -318,4 +320,18 @@ var _ = Describe(`Router`, func() {
 		})
 
 	})
+
+	Describe(`Args decrypting pre middleware`, func() {
+
+		It("Allow to use several args decrypting pre middleware, args are decrypted once", func() {
+			r := router.New(`decrypt`).
+				Pre(encryption.ArgsDecrypt, encryption.ArgsDecryptIfKeyProvided).
+				Query(`echo`, func(c router.Context) (interface{}, error) {
+					return c.ParamString(`value`), nil
+				}, param.String(`value`))
+
+			cc := encryption.NewMockStub(testcc.NewMockStub(`decrypt`, router.NewChaincode(r)), encKey)
+			Expect(expectcc.PayloadIs(cc.Query(`echo`, `some value`), ``)).To(Equal(`some value`))
+		})
+	})
 })
@@ -25,12 +25,17 @@ func ArgsDecryptExcept(exceptMethod ...string) router.ContextMiddlewareFunc {
 	}
 }
 
+// argsDecryptedKey marks context with already decrypted args, so args are not decrypted twice
+// if several args decrypting pre middleware are used
+const argsDecryptedKey = `_argsDecrypted`
+
 func decryptReplaceArgs(key []byte, c router.Context) error {
 	args, err := DecryptArgs(key, c.GetArgs())
 	if err != nil {
 		return errors.Wrap(err, `args`)
 	}
 	c.ReplaceArgs(args)
+	c.Set(argsDecryptedKey, true)
 	return nil
 }
 
@@ -38,6 +43,10 @@ func argsDecryptor(next router.ContextHandlerFunc, keyShouldBe bool, exceptMetho
 
 	return func(c router.Context) peer.Response {
 
+		if c.Get(argsDecryptedKey) == true {
+			return next(c)
+		}
+
 		// method exception - disable args decrypting
 		if len(exceptMethod) > 0 && len(c.GetArgs()) > 0 {
 			for _, m := range exceptMethod {
@@ -0,0 +1,78 @@
+package idempotency
+
+import (
+	"strconv"
+
+	"github.com/optherium/cckit/extensions/owner"
+	"github.com/optherium/cckit/router"
+	p "github.com/optherium/cckit/router/param"
+)
+
+const (
+	InvokeCleanupMethod = `requestIdCleanup`
+	ParamBefore         = `before`
+	ParamLimit          = `limit`
+
+	// DefaultCleanupLimit max number of records, deleted by one cleanup invoke
+	DefaultCleanupLimit = 1000
+)
+
+// InvokeCleanup deletes records of requests, processed before time from param,
+// at most limit records are deleted, invoke should be repeated while limit is reached
+func InvokeCleanup(c router.Context) (interface{}, error) {
+	return Cleanup(c, c.ParamTime(ParamBefore).Unix(), c.ParamInt(ParamLimit))
+}
+
+// Register adds cleanup method to router, middleware can be used for cleanup access control,
+// by default cleanup is allowed only to chaincode owner
+func Register(g *router.Group, middleware ...router.MiddlewareFunc) *router.Group {
+	if len(middleware) == 0 {
+		middleware = []router.MiddlewareFunc{owner.Only}
+	}
+	mw := make([]router.MiddlewareFunc, 0, len(middleware)+2)
+	mw = append(mw, middleware...)
+	mw = append(mw, p.Time(ParamBefore), p.Int(ParamLimit, p.Default(DefaultCleanupLimit), p.Min(1)))
+	return g.Invoke(InvokeCleanupMethod, InvokeCleanup, mw...)
+}
+
+// Cleanup deletes at most limit records of requests, processed before unix timestamp,
+// returns number of deleted records. Records are iterated in timestamp order
+func Cleanup(c router.Context, before int64, limit int) (int, error) {
+	iter, err := c.Stub().GetStateByPartialCompositeKey(RequestsTimeIndexKey, []string{})
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = iter.Close() }()
+
+	deleted := 0
+	for deleted < limit && iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return deleted, err
+		}
+		_, attrs, err := c.Stub().SplitCompositeKey(kv.Key)
+		if err != nil {
+			return deleted, err
+		}
+		if len(attrs) != 5 {
+			continue
+		}
+		timestamp, err := strconv.ParseInt(attrs[0], 10, 64)
+		if err != nil {
+			return deleted, err
+		}
+		if timestamp >= before {
+			break
+		}
+
+		record := Record{Timestamp: timestamp, MSP: attrs[1], Cert: attrs[2], Method: attrs[3], RequestId: attrs[4]}
+		if err = c.State().Delete(record); err != nil {
+			return deleted, err
+		}
+		if err = c.State().Delete(record.timeIndexKey()); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
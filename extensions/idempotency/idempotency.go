@@ -0,0 +1,226 @@
+// Package idempotency provides pre middleware, protecting chaincode from duplicate invokes
+// with the same client-supplied request id. Request ids are scoped by tx creator (MSP and cert id)
+// and chaincode method, so different clients or methods can't collide or replay each other responses
+package idempotency
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/protos/peer"
+
+	"github.com/optherium/cckit/errors"
+	"github.com/optherium/cckit/identity"
+	"github.com/optherium/cckit/router"
+)
+
+const (
+	// TransientMapKey key in transient map, containing request id
+	TransientMapKey = `REQUEST_ID`
+
+	// ArgPrefix prefix of reserved last arg, containing request id
+	ArgPrefix = `__requestId:`
+
+	// RequestsStateKey reserved state namespace for request records
+	RequestsStateKey = `_REQUEST_ID`
+
+	// RequestsTimeIndexKey reserved state namespace for index of request records by timestamp
+	RequestsTimeIndexKey = `_REQUEST_ID_TIME`
+)
+
+var (
+	// ErrDuplicateRequest occurs when request id is already processed
+	ErrDuplicateRequest = errors.NewCCError(409, `DUPLICATE_REQUEST`, `duplicate request`)
+
+	// ErrRequestArgsMismatch occurs when request id is already used for request with other args
+	ErrRequestArgsMismatch = errors.NewCCError(422, `REQUEST_ARGS_MISMATCH`, `request id used with other args`)
+)
+
+type (
+	// Record of processed request, stored in chaincode state
+	Record struct {
+		MSP          string
+		Cert         string
+		Method       string
+		RequestId    string
+		ArgsHash     string
+		TxId         string
+		Timestamp    int64
+		ResponseHash string
+		Response     peer.Response
+	}
+)
+
+// Key implements state.Keyer interface
+func (r Record) Key() ([]string, error) {
+	return []string{RequestsStateKey, r.MSP, r.Cert, r.Method, r.RequestId}, nil
+}
+
+// timeIndexKey returns key of record in timestamp index, zero padded timestamp keeps index ordered by time
+func (r Record) timeIndexKey() []string {
+	return []string{RequestsTimeIndexKey, fmt.Sprintf(`%020d`, r.Timestamp), r.MSP, r.Cert, r.Method, r.RequestId}
+}
+
+// Replay pre middleware returns original response for request with already processed request id
+func Replay(next router.ContextHandlerFunc, pos ...int) router.ContextHandlerFunc {
+	return handle(next, true, nil)
+}
+
+// Reject pre middleware returns ErrDuplicateRequest for request with already processed request id
+func Reject(next router.ContextHandlerFunc, pos ...int) router.ContextHandlerFunc {
+	return handle(next, false, nil)
+}
+
+// ReplayWithArg pre middleware works as Replay, for methods (paths) request id is also taken from reserved last arg
+func ReplayWithArg(paths ...string) router.ContextMiddlewareFunc {
+	return func(next router.ContextHandlerFunc, pos ...int) router.ContextHandlerFunc {
+		return handle(next, true, paths)
+	}
+}
+
+// RejectWithArg pre middleware works as Reject, for methods (paths) request id is also taken from reserved last arg
+func RejectWithArg(paths ...string) router.ContextMiddlewareFunc {
+	return func(next router.ContextHandlerFunc, pos ...int) router.ContextHandlerFunc {
+		return handle(next, false, paths)
+	}
+}
+
+// RequestId returns request id from transient map. If fromArg is true, request id is taken
+// from reserved last arg first, reserved arg is removed from args
+func RequestId(c router.Context, fromArg bool) (string, error) {
+	args := c.GetArgs()
+	if fromArg && len(args) > 1 && bytes.HasPrefix(args[len(args)-1], []byte(ArgPrefix)) {
+		requestId := string(args[len(args)-1][len(ArgPrefix):])
+		c.ReplaceArgs(args[:len(args)-1])
+		return requestId, nil
+	}
+
+	transient, err := c.Stub().GetTransient()
+	if err != nil {
+		return ``, err
+	}
+	return string(transient[TransientMapKey]), nil
+}
+
+// Get returns record of request, processed for tx creator and chaincode method
+func Get(c router.Context, requestId string) (*Record, error) {
+	scoped, err := newRecord(c, requestId)
+	if err != nil {
+		return nil, err
+	}
+	key, err := scoped.Key()
+	if err != nil {
+		return nil, err
+	}
+	if exists, err := c.State().Exists(key); err != nil || !exists {
+		return nil, err
+	}
+
+	record, err := c.State().Get(key, &Record{})
+	if err != nil {
+		return nil, err
+	}
+	r := record.(Record)
+	return &r, nil
+}
+
+func handle(next router.ContextHandlerFunc, replay bool, argPaths []string) router.ContextHandlerFunc {
+	return func(c router.Context) peer.Response {
+		requestId, err := RequestId(c, contains(argPaths, c.Path()))
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		if requestId == `` {
+			return next(c)
+		}
+
+		record, err := Get(c, requestId)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+
+		hash := argsHash(c)
+		if record != nil && record.ArgsHash != hash {
+			return ErrRequestArgsMismatch.
+				WithDetail(`requestId`, requestId).
+				WithDetail(`txId`, record.TxId).Response()
+		}
+
+		if record != nil {
+			c.Logger().Warningf(`duplicate request %s, original tx %s`, requestId, record.TxId)
+			if replay {
+				return record.Response
+			}
+			return ErrDuplicateRequest.
+				WithDetail(`requestId`, requestId).
+				WithDetail(`txId`, record.TxId).Response()
+		}
+
+		res := next(c)
+		// failed tx will not be committed, so only successful responses are recorded
+		if res.Status >= shim.ERRORTHRESHOLD {
+			return res
+		}
+
+		if err = put(c, requestId, hash, res); err != nil {
+			return shim.Error(err.Error())
+		}
+		return res
+	}
+}
+
+func put(c router.Context, requestId, argsHash string, res peer.Response) error {
+	txTime, err := c.Time()
+	if err != nil {
+		return err
+	}
+
+	record, err := newRecord(c, requestId)
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(res.Payload)
+	record.ArgsHash = argsHash
+	record.TxId = c.Stub().GetTxID()
+	record.Timestamp = txTime.Unix()
+	record.ResponseHash = hex.EncodeToString(hash[:])
+	record.Response = res
+	if err = c.State().Put(record); err != nil {
+		return err
+	}
+	return c.State().Put(record.timeIndexKey(), []byte(record.TxId))
+}
+
+// newRecord creates record of request, scoped by tx creator and chaincode method
+func newRecord(c router.Context, requestId string) (*Record, error) {
+	creator, err := identity.FromStub(c.Stub())
+	if err != nil {
+		return nil, err
+	}
+	return &Record{MSP: creator.GetMSPID(), Cert: creator.GetID(), Method: c.Path(), RequestId: requestId}, nil
+}
+
+// argsHash returns hash of method args, without reserved request id arg
+func argsHash(c router.Context) string {
+	h := sha256.New()
+	for _, arg := range c.GetArgs() {
+		l := make([]byte, 8)
+		binary.BigEndian.PutUint64(l, uint64(len(arg)))
+		h.Write(l)
+		h.Write(arg)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package idempotency_test
+
+import (
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/optherium/cckit/extensions/idempotency"
+	"github.com/optherium/cckit/extensions/owner"
+	"github.com/optherium/cckit/router"
+	p "github.com/optherium/cckit/router/param"
+	testcc "github.com/optherium/cckit/testing"
+	expectcc "github.com/optherium/cckit/testing/expect"
+)
+
+func TestIdempotency(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Idempotency suite")
+}
+
+const CounterKey = `counter`
+
+func increment(c router.Context) (interface{}, error) {
+	counter, err := c.State().GetInt(CounterKey, 0)
+	if err != nil {
+		return nil, err
+	}
+	counter++
+	return counter, c.State().Put(CounterKey, counter)
+}
+
+func add(c router.Context) (interface{}, error) {
+	counter, err := c.State().GetInt(CounterKey, 0)
+	if err != nil {
+		return nil, err
+	}
+	counter += c.ParamInt(`value`)
+	return counter, c.State().Put(CounterKey, counter)
+}
+
+func New(middleware router.ContextMiddlewareFunc) *router.Chaincode {
+	r := router.New(`idempotent`).
+		Pre(middleware).
+		Init(owner.InvokeSetFromCreator).
+		Invoke(`increment`, increment).
+		Invoke(`add`, add, p.Int(`value`))
+
+	idempotency.Register(r)
+	return router.NewChaincode(r)
+}
+
+func withRequestId(id string) map[string][]byte {
+	return map[string][]byte{idempotency.TransientMapKey: []byte(id)}
+}
+
+var _ = Describe(`Idempotency`, func() {
+
+	ccOwner := testcc.MustGenerateIdentity(`SOME_MSP`, pkix.Name{CommonName: `owner`}, nil)
+	client := testcc.MustGenerateIdentity(`SOME_MSP`, pkix.Name{CommonName: `client`}, nil)
+	otherClient := testcc.MustGenerateIdentity(`SOME_MSP`, pkix.Name{CommonName: `other client`}, nil)
+
+	replayCC := testcc.NewMockStub(`replay`, New(idempotency.Replay))
+	rejectCC := testcc.NewMockStub(`reject`, New(idempotency.Reject))
+	argCC := testcc.NewMockStub(`arg`, New(idempotency.ReplayWithArg(`increment`)))
+
+	BeforeSuite(func() {
+		for _, cc := range []*testcc.MockStub{replayCC, rejectCC, argCC} {
+			expectcc.ResponseOk(cc.From(ccOwner).Init())
+		}
+	})
+
+	It(`Allow invokes without request id`, func() {
+		expectcc.PayloadInt(replayCC.From(client).Invoke(`increment`), 1)
+		expectcc.PayloadInt(replayCC.From(client).Invoke(`increment`), 2)
+	})
+
+	It(`Replay original response for duplicate request id`, func() {
+		expectcc.PayloadInt(replayCC.From(client).WithTransient(withRequestId(`req-1`)).Invoke(`increment`), 3)
+		expectcc.PayloadInt(replayCC.From(client).WithTransient(withRequestId(`req-1`)).Invoke(`increment`), 3)
+		expectcc.PayloadInt(replayCC.From(client).Invoke(`increment`), 4)
+	})
+
+	It(`Allow to use the same request id by other creator or for other method`, func() {
+		expectcc.PayloadInt(replayCC.From(otherClient).WithTransient(withRequestId(`req-1`)).Invoke(`increment`), 5)
+		expectcc.PayloadInt(replayCC.From(client).WithTransient(withRequestId(`req-1`)).Invoke(`add`, 10), 15)
+	})
+
+	It(`Disallow to use request id with other args`, func() {
+		mismatch := expectcc.ResponseErrorIs(
+			replayCC.From(client).WithTransient(withRequestId(`req-1`)).Invoke(`add`, 20), idempotency.ErrRequestArgsMismatch)
+		Expect(mismatch.Details[`requestId`]).To(Equal(`req-1`))
+	})
+
+	It(`Allow to pass request id in reserved arg for opted-in methods`, func() {
+		expectcc.PayloadInt(argCC.From(client).Invoke(`increment`, idempotency.ArgPrefix+`req-2`), 1)
+		expectcc.PayloadInt(argCC.From(client).Invoke(`increment`, idempotency.ArgPrefix+`req-2`), 1)
+
+		// request id is not taken from reserved arg of other methods
+		expectcc.PayloadInt(argCC.From(client).Invoke(`add`, 1, idempotency.ArgPrefix+`req-3`), 2)
+		expectcc.PayloadInt(argCC.From(client).Invoke(`add`, 1, idempotency.ArgPrefix+`req-3`), 3)
+	})
+
+	It(`Reject duplicate request id`, func() {
+		expectcc.PayloadInt(rejectCC.From(client).WithTransient(withRequestId(`req-1`)).Invoke(`increment`), 1)
+		dup := expectcc.ResponseErrorIs(
+			rejectCC.From(client).WithTransient(withRequestId(`req-1`)).Invoke(`increment`), idempotency.ErrDuplicateRequest)
+		Expect(dup.Details[`requestId`]).To(Equal(`req-1`))
+	})
+
+	It(`Disallow to cleanup request ids by not owner`, func() {
+		expectcc.ResponseError(
+			replayCC.From(client).Invoke(idempotency.InvokeCleanupMethod, time.Now().Add(time.Hour)), owner.ErrOwnerOnly)
+	})
+
+	It(`Allow to cleanup old request ids with limit`, func() {
+		expectcc.PayloadInt(replayCC.From(ccOwner).Invoke(idempotency.InvokeCleanupMethod, time.Now().Add(-time.Hour)), 0)
+		expectcc.PayloadInt(replayCC.From(ccOwner).Invoke(idempotency.InvokeCleanupMethod, time.Now().Add(time.Hour), 2), 2)
+		expectcc.PayloadInt(replayCC.From(ccOwner).Invoke(idempotency.InvokeCleanupMethod, time.Now().Add(time.Hour), 2), 1)
+		expectcc.PayloadInt(replayCC.From(client).WithTransient(withRequestId(`req-1`)).Invoke(`increment`), 16)
+	})
+})
@@ -0,0 +1,49 @@
+package rbac
+
+import (
+	"github.com/optherium/cckit/router"
+	p "github.com/optherium/cckit/router/param"
+)
+
+const (
+	InvokeGrantMethod  = `rbacGrant`
+	InvokeRevokeMethod = `rbacRevoke`
+	QueryRolesMethod   = `rbacRoles`
+	QueryHasRoleMethod = `rbacHasRole`
+
+	ParamIdentity = `identity`
+	ParamRole     = `role`
+)
+
+// Register adds grant, revoke, list roles and has role methods to router
+func (r *RBAC) Register(g *router.Group) *router.Group {
+	return g.
+		Invoke(InvokeGrantMethod, r.InvokeGrant, p.Identity(ParamIdentity), p.String(ParamRole, p.Required())).
+		Invoke(InvokeRevokeMethod, r.InvokeRevoke, p.Identity(ParamIdentity), p.String(ParamRole, p.Required())).
+		Query(QueryRolesMethod, QueryRoles, p.Identity(ParamIdentity)).
+		Query(QueryHasRoleMethod, QueryHasRole, p.Identity(ParamIdentity), p.String(ParamRole))
+}
+
+// InvokeGrant grants role to identity
+func (r *RBAC) InvokeGrant(c router.Context) (interface{}, error) {
+	return nil, r.Grant(c, c.ParamIdentity(ParamIdentity), c.ParamString(ParamRole))
+}
+
+// InvokeRevoke revokes role from identity
+func (r *RBAC) InvokeRevoke(c router.Context) (interface{}, error) {
+	return nil, r.Revoke(c, c.ParamIdentity(ParamIdentity), c.ParamString(ParamRole))
+}
+
+// QueryRoles returns roles granted to identity
+func QueryRoles(c router.Context) (interface{}, error) {
+	roles, err := Roles(c, c.ParamIdentity(ParamIdentity))
+	if roles == nil {
+		roles = []string{}
+	}
+	return roles, err
+}
+
+// QueryHasRole checks identity has role
+func QueryHasRole(c router.Context) (interface{}, error) {
+	return HasAnyRole(c, c.ParamIdentity(ParamIdentity), c.ParamString(ParamRole))
+}
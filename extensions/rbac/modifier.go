@@ -0,0 +1,25 @@
+package rbac
+
+import (
+	"fmt"
+
+	"github.com/optherium/cckit/router"
+)
+
+// Only allows access to tx creators having one of roles
+func Only(roles ...string) router.MiddlewareFunc {
+	return func(next router.HandlerFunc, pos ...int) router.HandlerFunc {
+		return func(c router.Context) (interface{}, error) {
+			invoker, err := InvokerId(c)
+			if err != nil {
+				return nil, ErrRoleRequired.WithDetail(`error`, err.Error())
+			}
+
+			hasRole, err := HasAnyRole(c, invoker, roles...)
+			if err != nil || !hasRole {
+				return nil, ErrRoleRequired.WithDetail(`roles`, fmt.Sprint(roles))
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Package rbac provides role-based access management with roles-to-identities assignments stored in chaincode state.
+// Assignments use extensions/acl state layout, so acl.Role rules can be used with roles granted by rbac
+package rbac
+
+import (
+	"github.com/optherium/cckit/errors"
+	"github.com/optherium/cckit/extensions/acl"
+	"github.com/optherium/cckit/extensions/owner"
+	"github.com/optherium/cckit/identity"
+	"github.com/optherium/cckit/router"
+)
+
+// SuperAdminRole holders can grant and revoke any role, chaincode owner is initial super admin
+const SuperAdminRole = `superadmin`
+
+const (
+	EventRoleGranted = `RoleGranted`
+	EventRoleRevoked = `RoleRevoked`
+)
+
+var (
+	// ErrRoleRequired occurs when invoker has none of roles, required to call chaincode method
+	ErrRoleRequired = errors.NewCCError(403, `ROLE_REQUIRED`, `role required`)
+
+	// ErrNotRoleAdmin occurs when invoker is not allowed to grant or revoke role
+	ErrNotRoleAdmin = errors.NewCCError(403, `NOT_ROLE_ADMIN`, `not role admin`)
+
+	// ErrRoleAlreadyGranted occurs when granting role, already granted to identity
+	ErrRoleAlreadyGranted = errors.NewCCError(409, `ROLE_ALREADY_GRANTED`, `role already granted`)
+
+	// ErrRoleNotGranted occurs when revoking role, not granted to identity
+	ErrRoleNotGranted = errors.NewCCError(404, `ROLE_NOT_GRANTED`, `role not granted`)
+)
+
+type (
+	// RBAC role-based access management, holds roles admins configuration
+	RBAC struct {
+		admins map[string][]string
+	}
+
+	// RoleChange event payload, emitted when role is granted or revoked
+	RoleChange struct {
+		Id   identity.Id
+		Role string
+		By   identity.Id
+	}
+)
+
+// New creates RBAC, by default only super admins can grant and revoke roles
+func New() *RBAC {
+	return &RBAC{admins: make(map[string][]string)}
+}
+
+// Admins allows holders of adminRoles to grant and revoke role
+func (r *RBAC) Admins(role string, adminRoles ...string) *RBAC {
+	r.admins[role] = append(r.admins[role], adminRoles...)
+	return r
+}
+
+// IsAdmin checks tx creator is allowed to grant and revoke role
+func (r *RBAC) IsAdmin(c router.Context, role string) (bool, error) {
+	if isOwner, err := owner.IsInvoker(c); isOwner && err == nil {
+		return true, nil
+	}
+
+	invoker, err := InvokerId(c)
+	if err != nil {
+		return false, err
+	}
+	return HasAnyRole(c, invoker, append([]string{SuperAdminRole}, r.admins[role]...)...)
+}
+
+// Grant adds role to identity, tx creator must be role admin
+func (r *RBAC) Grant(c router.Context, id identity.Id, role string) error {
+	roles, by, err := r.adminChange(c, id, role)
+	if err != nil {
+		return err
+	}
+
+	for _, granted := range roles {
+		if granted == role {
+			return ErrRoleAlreadyGranted.WithDetail(`role`, role)
+		}
+	}
+
+	if err = acl.SetRoles(c, id, append(roles, role)...); err != nil {
+		return err
+	}
+	return c.Event().Set(EventRoleGranted, &RoleChange{Id: id, Role: role, By: by})
+}
+
+// Revoke removes role from identity, tx creator must be role admin
+func (r *RBAC) Revoke(c router.Context, id identity.Id, role string) error {
+	roles, by, err := r.adminChange(c, id, role)
+	if err != nil {
+		return err
+	}
+
+	var (
+		remaining []string
+		found     bool
+	)
+	for _, granted := range roles {
+		if granted == role {
+			found = true
+		} else {
+			remaining = append(remaining, granted)
+		}
+	}
+
+	if !found {
+		return ErrRoleNotGranted.WithDetail(`role`, role)
+	}
+
+	if err = acl.SetRoles(c, id, remaining...); err != nil {
+		return err
+	}
+	return c.Event().Set(EventRoleRevoked, &RoleChange{Id: id, Role: role, By: by})
+}
+
+func (r *RBAC) adminChange(c router.Context, id identity.Id, role string) (roles []string, by identity.Id, err error) {
+	isAdmin, err := r.IsAdmin(c, role)
+	if err != nil {
+		return nil, by, err
+	}
+	if !isAdmin {
+		return nil, by, ErrNotRoleAdmin.WithDetail(`role`, role)
+	}
+
+	if by, err = InvokerId(c); err != nil {
+		return nil, by, err
+	}
+
+	roles, err = Roles(c, id)
+	return roles, by, err
+}
+
+// Roles returns roles, granted to identity
+func Roles(c router.Context, id identity.Id) ([]string, error) {
+	return acl.GetRoles(c, id)
+}
+
+// HasAnyRole checks identity has one of roles
+func HasAnyRole(c router.Context, id identity.Id, roles ...string) (bool, error) {
+	granted, err := Roles(c, id)
+	if err != nil {
+		return false, err
+	}
+
+	for _, g := range granted {
+		for _, role := range roles {
+			if g == role {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// InvokerId returns tx creator identity.Id
+func InvokerId(c router.Context) (identity.Id, error) {
+	invoker, err := identity.FromStub(c.Stub())
+	if err != nil {
+		return identity.Id{}, err
+	}
+	return identity.Id{MSP: invoker.GetMSPID(), Cert: invoker.GetID()}, nil
+}
@@ -0,0 +1,87 @@
+package rbac_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/optherium/cckit/examples/cert"
+	"github.com/optherium/cckit/extensions/owner"
+	"github.com/optherium/cckit/extensions/rbac"
+	"github.com/optherium/cckit/identity"
+	"github.com/optherium/cckit/router"
+	testcc "github.com/optherium/cckit/testing"
+	expectcc "github.com/optherium/cckit/testing/expect"
+)
+
+func TestRBAC(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RBAC suite")
+}
+
+const (
+	RoleAuditor      = `auditor`
+	RoleAuditManager = `auditManager`
+)
+
+func New() *router.Chaincode {
+	r := router.New(`rbac`).
+		Init(owner.InvokeSetFromCreator).
+		Query(`audit`, router.EmptyContextHandler, rbac.Only(RoleAuditor))
+
+	rbac.New().Admins(RoleAuditor, RoleAuditManager).Register(r)
+	return router.NewChaincode(r)
+}
+
+var _ = Describe(`RBAC`, func() {
+
+	cc := testcc.NewMockStub(`rbac`, New())
+	actors := testcc.MustIdentitiesFromFiles(`SOME_MSP`, map[string]string{
+		`owner`:   `s7techlab.pem`,
+		`manager`: `victor-nosov.pem`,
+		`someone`: `some-person.pem`}, cert.Content)
+
+	manager := identity.Id{MSP: `SOME_MSP`, Cert: actors[`manager`].GetID()}
+	someone := identity.Id{MSP: `SOME_MSP`, Cert: actors[`someone`].GetID()}
+
+	BeforeSuite(func() {
+		expectcc.ResponseOk(cc.From(actors[`owner`]).Init())
+	})
+
+	It(`Allow owner to grant roles as super admin`, func() {
+		expectcc.ResponseErrorIs(cc.From(actors[`manager`]).Invoke(rbac.InvokeGrantMethod, manager, RoleAuditManager),
+			rbac.ErrNotRoleAdmin)
+
+		expectcc.ResponseOk(cc.From(actors[`owner`]).Invoke(rbac.InvokeGrantMethod, manager, RoleAuditManager))
+		change := expectcc.EventPayloadIs(cc.ChaincodeEvent, &rbac.RoleChange{}).(rbac.RoleChange)
+		Expect(cc.ChaincodeEvent.EventName).To(Equal(rbac.EventRoleGranted))
+		Expect(change.Id).To(Equal(manager))
+		Expect(change.Role).To(Equal(RoleAuditManager))
+
+		expectcc.ResponseErrorIs(cc.From(actors[`owner`]).Invoke(rbac.InvokeGrantMethod, manager, RoleAuditManager),
+			rbac.ErrRoleAlreadyGranted)
+	})
+
+	It(`Allow role admin to grant and revoke role`, func() {
+		expectcc.ResponseErrorIs(cc.From(actors[`someone`]).Query(`audit`), rbac.ErrRoleRequired)
+
+		expectcc.ResponseOk(cc.From(actors[`manager`]).Invoke(rbac.InvokeGrantMethod, someone, RoleAuditor))
+		Expect(expectcc.PayloadIs(cc.Query(rbac.QueryRolesMethod, someone), &[]string{})).To(Equal([]string{RoleAuditor}))
+		Expect(expectcc.PayloadIs(cc.Query(rbac.QueryHasRoleMethod, someone, RoleAuditor), true)).To(BeTrue())
+		expectcc.ResponseOk(cc.From(actors[`someone`]).Query(`audit`))
+
+		expectcc.ResponseOk(cc.From(actors[`manager`]).Invoke(rbac.InvokeRevokeMethod, someone, RoleAuditor))
+		Expect(cc.ChaincodeEvent.EventName).To(Equal(rbac.EventRoleRevoked))
+		Expect(expectcc.PayloadIs(cc.Query(rbac.QueryRolesMethod, someone), &[]string{})).To(Equal([]string{}))
+		expectcc.ResponseErrorIs(cc.From(actors[`someone`]).Query(`audit`), rbac.ErrRoleRequired)
+
+		expectcc.ResponseErrorIs(cc.From(actors[`manager`]).Invoke(rbac.InvokeRevokeMethod, someone, RoleAuditor),
+			rbac.ErrRoleNotGranted)
+	})
+
+	It(`Disallow role admin to grant other roles`, func() {
+		expectcc.ResponseErrorIs(cc.From(actors[`manager`]).Invoke(rbac.InvokeGrantMethod, someone, rbac.SuperAdminRole),
+			rbac.ErrNotRoleAdmin)
+	})
+})
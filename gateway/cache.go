@@ -0,0 +1,285 @@
+package gateway
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/optherium/cckit/gateway/service"
+)
+
+type (
+	// QueryCache caches query results (response payloads) with TTL and size limit, keyed by channel, chaincode,
+	// fn, args, caller identity and transient map. Cached results are invalidated by chaincode events
+	// with rules, set by InvalidateOn
+	QueryCache struct {
+		// TTL default time to live of cached result, 0 - results are not expired
+		TTL time.Duration
+		// MaxSize max number of cached results, least recently used results are evicted, 0 - not limited
+		MaxSize int
+
+		m       sync.Mutex
+		methods map[string]CacheMethod
+		// event name -> fns
+		rules   map[string][]string
+		entries map[[sha256.Size]byte]*list.Element
+		lru     *list.List
+		stats   CacheStats
+		// incremented on invalidation, results of queries, started before invalidation, are not cached
+		epoch uint64
+	}
+
+	// CacheMethod per method (fn) cache settings, i.e. for generated gateway method constants (CPaperChaincode_Get)
+	CacheMethod struct {
+		// TTL overrides default time to live
+		TTL time.Duration
+		// Disabled method results are not cached
+		Disabled bool
+	}
+
+	// CacheStats query cache statistics
+	CacheStats struct {
+		Hits          uint64
+		Misses        uint64
+		Evictions     uint64
+		Invalidations uint64
+		Size          int
+	}
+
+	cacheEntry struct {
+		key       [sha256.Size]byte
+		chaincode string
+		fn        string
+		payload   []byte
+		expires   time.Time
+	}
+)
+
+// NewQueryCache creates query cache with default TTL and max size, 0 - not limited
+func NewQueryCache(ttl time.Duration, maxSize int) *QueryCache {
+	return &QueryCache{
+		TTL:     ttl,
+		MaxSize: maxSize,
+		methods: make(map[string]CacheMethod),
+		rules:   make(map[string][]string),
+		entries: make(map[[sha256.Size]byte]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Method sets cache settings for method (fn)
+func (c *QueryCache) Method(fn string, method CacheMethod) *QueryCache {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.methods[fn] = method
+	return c
+}
+
+// InvalidateOn sets rule: event invalidates cached results of methods (fns) of chaincode, event is emitted by.
+// Without fns event invalidates all cached results of chaincode
+func (c *QueryCache) InvalidateOn(eventName string, fns ...string) *QueryCache {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	existing, ok := c.rules[eventName]
+	switch {
+	case ok && len(existing) == 0:
+		// event already invalidates all methods
+	case len(fns) == 0:
+		c.rules[eventName] = []string{}
+	default:
+		c.rules[eventName] = append(existing, fns...)
+	}
+	return c
+}
+
+// Watch invalidates cached results with events of subscription until subscription ends.
+// Watch consumes Events channel of subscription, so subscription must be dedicated to cache
+func (c *QueryCache) Watch(sub ChaincodeEventSub) {
+	go func() {
+		for e := range sub.Events() {
+			c.InvalidateEvent(e)
+		}
+	}()
+}
+
+// InvalidateEvent invalidates cached results according to rules for event name.
+// If event chaincode is not set, results of all chaincodes are invalidated
+func (c *QueryCache) InvalidateEvent(e *peer.ChaincodeEvent) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	fns, ok := c.rules[e.EventName]
+	if !ok {
+		return
+	}
+	if e.ChaincodeId == `` {
+		c.invalidate(func(entry *cacheEntry) bool { return matchFn(fns, entry.fn) })
+		return
+	}
+	c.invalidate(matchEntry(e.ChaincodeId, fns))
+}
+
+// Invalidate invalidates cached results of chaincode methods (fns), without fns - all results of chaincode
+func (c *QueryCache) Invalidate(chaincode string, fns ...string) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.invalidate(matchEntry(chaincode, fns))
+}
+
+// Stats returns hit/miss statistics
+func (c *QueryCache) Stats() CacheStats {
+	c.m.Lock()
+	defer c.m.Unlock()
+	stats := c.stats
+	stats.Size = c.lru.Len()
+	return stats
+}
+
+// invalidate removes matched entries and starts new epoch, must be called under lock
+func (c *QueryCache) invalidate(match func(*cacheEntry) bool) {
+	c.epoch++
+	for el := c.lru.Front(); el != nil; {
+		next := el.Next()
+		if match(el.Value.(*cacheEntry)) {
+			c.remove(el)
+			c.stats.Invalidations++
+		}
+		el = next
+	}
+}
+
+// matchEntry matches entries of chaincode methods, empty fns match all methods
+func matchEntry(chaincode string, fns []string) func(*cacheEntry) bool {
+	return func(entry *cacheEntry) bool {
+		return entry.chaincode == chaincode && matchFn(fns, entry.fn)
+	}
+}
+
+func matchFn(fns []string, fn string) bool {
+	if len(fns) == 0 {
+		return true
+	}
+	for _, f := range fns {
+		if f == fn {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *QueryCache) remove(el *list.Element) {
+	c.lru.Remove(el)
+	delete(c.entries, el.Value.(*cacheEntry).key)
+}
+
+func (c *QueryCache) enabled(fn string) bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return !c.methods[fn].Disabled
+}
+
+// get returns cached payload and current epoch
+func (c *QueryCache) get(key [sha256.Size]byte) ([]byte, uint64, bool) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	el, ok := c.entries[key]
+	if ok && el.Value.(*cacheEntry).expired(time.Now()) {
+		c.remove(el)
+		ok = false
+	}
+	if !ok {
+		c.stats.Misses++
+		return nil, c.epoch, false
+	}
+
+	c.stats.Hits++
+	c.lru.MoveToFront(el)
+	return el.Value.(*cacheEntry).payload, c.epoch, true
+}
+
+// put caches payload, if cache is not invalidated since epoch, payload is queried in
+func (c *QueryCache) put(key [sha256.Size]byte, epoch uint64, chaincode, fn string, payload []byte) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if epoch != c.epoch {
+		return
+	}
+
+	entry := &cacheEntry{key: key, chaincode: chaincode, fn: fn, payload: payload}
+	ttl := c.TTL
+	if method, ok := c.methods[fn]; ok && method.TTL > 0 {
+		ttl = method.TTL
+	}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(ttl)
+	}
+
+	if el, ok := c.entries[key]; ok {
+		el.Value = entry
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+
+	for c.MaxSize > 0 && c.lru.Len() > c.MaxSize {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+func (e *cacheEntry) expired(now time.Time) bool {
+	return !e.expires.IsZero() && now.After(e.expires)
+}
+
+// queryCacheKey hashes query location, plain args, caller identity and transient map,
+// so results, depending on caller or transient values (i.e. encryption key), are not shared
+func queryCacheKey(ctx context.Context, channel, chaincode, fn string, args [][]byte) ([sha256.Size]byte, error) {
+	h := sha256.New()
+	write := func(b []byte) {
+		l := make([]byte, 8)
+		binary.BigEndian.PutUint64(l, uint64(len(b)))
+		h.Write(l)
+		h.Write(b)
+	}
+
+	write([]byte(channel))
+	write([]byte(chaincode))
+	write([]byte(fn))
+	for _, arg := range args {
+		write(arg)
+	}
+
+	if signer, err := service.SignerFromContext(ctx); err == nil {
+		id, err := signer.Serialize()
+		if err != nil {
+			return [sha256.Size]byte{}, err
+		}
+		write(id)
+	}
+
+	transient, err := TransientFromContext(ctx)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	keys := make([]string, 0, len(transient))
+	for k := range transient {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		write([]byte(k))
+		write(transient[k])
+	}
+
+	var key [sha256.Size]byte
+	copy(key[:], h.Sum(nil))
+	return key, nil
+}
@@ -0,0 +1,205 @@
+package gateway_test
+
+import (
+	"context"
+	"crypto/x509/pkix"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hyperledger/fabric/protos/peer"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/optherium/cckit/examples/cpaper_asservice"
+	"github.com/optherium/cckit/examples/cpaper_asservice/schema"
+	cpaperservice "github.com/optherium/cckit/examples/cpaper_asservice/service"
+	"github.com/optherium/cckit/gateway"
+	"github.com/optherium/cckit/gateway/service"
+	testcc "github.com/optherium/cckit/testing"
+)
+
+// CountingService counts queries, reached chaincode
+type CountingService struct {
+	*service.MockChaincodeService
+
+	m       sync.Mutex
+	queries int
+}
+
+func (s *CountingService) Query(ctx context.Context, in *service.ChaincodeInput) (*peer.ProposalResponse, error) {
+	s.m.Lock()
+	s.queries++
+	s.m.Unlock()
+	return s.MockChaincodeService.Query(ctx, in)
+}
+
+func (s *CountingService) Queries() int {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.queries
+}
+
+// InvalidatingService invalidates cache while query is processed
+type InvalidatingService struct {
+	*CountingService
+	Cache *gateway.QueryCache
+}
+
+func (s *InvalidatingService) Query(ctx context.Context, in *service.ChaincodeInput) (*peer.ProposalResponse, error) {
+	res, err := s.CountingService.Query(ctx, in)
+	s.Cache.Invalidate(in.Chaincode)
+	return res, err
+}
+
+var _ = Describe(`Query cache`, func() {
+
+	identity := testcc.MustGenerateIdentity(`MSP`, pkix.Name{CommonName: `issuer`}, nil)
+	ctx := context.Background()
+
+	issue := func(number string) *schema.IssueCommercialPaper {
+		return &schema.IssueCommercialPaper{
+			Issuer:       `SomeIssuer`,
+			PaperNumber:  number,
+			IssueDate:    testcc.MustProtoTimestamp(time.Now()),
+			MaturityDate: testcc.MustProtoTimestamp(time.Now().AddDate(0, 2, 0)),
+			FaceValue:    100000,
+			ExternalId:   `EXT` + number,
+		}
+	}
+	paperId := func(number string) *schema.CommercialPaperId {
+		return &schema.CommercialPaperId{Issuer: `SomeIssuer`, PaperNumber: number}
+	}
+
+	newCPaper := func(cache *gateway.QueryCache) (*CountingService, *cpaperservice.CPaperGateway) {
+		cc, err := cpaper_asservice.NewCC()
+		Expect(err).NotTo(HaveOccurred())
+		ccService := &CountingService{
+			MockChaincodeService: service.NewMock().WithChannel(`cpaper`, testcc.NewMockStub(`cpaper`, cc)),
+		}
+		return ccService, cpaperservice.NewCPaperGateway(ccService, `cpaper`, `cpaper`,
+			gateway.WithDefaultSigner(identity), gateway.WithQueryCache(cache))
+	}
+
+	It(`Allow to return cached query result`, func() {
+		cache := gateway.NewQueryCache(time.Minute, 0)
+		ccService, cpaper := newCPaper(cache)
+
+		_, err := cpaper.Issue(ctx, issue(`0001`))
+		Expect(err).NotTo(HaveOccurred())
+
+		for i := 0; i < 3; i++ {
+			paper, err := cpaper.Get(ctx, paperId(`0001`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(paper.ExternalId).To(Equal(`EXT0001`))
+		}
+
+		Expect(ccService.Queries()).To(Equal(1))
+		Expect(cache.Stats()).To(Equal(gateway.CacheStats{Hits: 2, Misses: 1, Size: 1}))
+
+		// other args - other cache key
+		_, err = cpaper.Get(ctx, paperId(`0002`))
+		Expect(err).To(HaveOccurred())
+		Expect(ccService.Queries()).To(Equal(2))
+		// error responses are not cached
+		Expect(cache.Stats().Size).To(Equal(1))
+	})
+
+	It(`Allow to invalidate cached results with chaincode events`, func() {
+		cache := gateway.NewQueryCache(time.Minute, 0).
+			InvalidateOn(`IssueCommercialPaper`, cpaperservice.CPaperChaincode_List)
+		ccService, cpaper := newCPaper(cache)
+
+		sub, err := cpaper.Events(ctx, gateway.WithBlockRange(1, 0))
+		Expect(err).NotTo(HaveOccurred())
+		defer sub.Close()
+		cache.Watch(sub)
+
+		_, err = cpaper.Issue(ctx, issue(`0001`))
+		Expect(err).NotTo(HaveOccurred())
+
+		list, err := cpaper.List(ctx, &empty.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.Items).To(HaveLen(1))
+		_, err = cpaper.Get(ctx, paperId(`0001`))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = cpaper.Issue(ctx, issue(`0002`))
+		Expect(err).NotTo(HaveOccurred())
+
+		// list is invalidated by event, paper is not
+		Eventually(func() int {
+			list, err := cpaper.List(ctx, &empty.Empty{})
+			Expect(err).NotTo(HaveOccurred())
+			return len(list.Items)
+		}).Should(Equal(2))
+		queries := ccService.Queries()
+		paper, err := cpaper.Get(ctx, paperId(`0001`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paper.ExternalId).To(Equal(`EXT0001`))
+		Expect(ccService.Queries()).To(Equal(queries))
+		Expect(cache.Stats().Invalidations).To(BeNumerically(`>=`, 1))
+	})
+
+	It(`Disallow to cache result of query, invalidated while query was processed`, func() {
+		cache := gateway.NewQueryCache(time.Minute, 0)
+		ccService, cpaper := newCPaper(cache)
+		_, err := cpaper.Issue(ctx, issue(`0001`))
+		Expect(err).NotTo(HaveOccurred())
+
+		invalidating := &InvalidatingService{CountingService: ccService, Cache: cache}
+		cpaper = cpaperservice.NewCPaperGateway(invalidating, `cpaper`, `cpaper`,
+			gateway.WithDefaultSigner(identity), gateway.WithQueryCache(cache))
+
+		for i := 0; i < 2; i++ {
+			_, err = cpaper.Get(ctx, paperId(`0001`))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(ccService.Queries()).To(Equal(2))
+		Expect(cache.Stats().Size).To(Equal(0))
+	})
+
+	It(`Allow to configure cache per method`, func() {
+		cache := gateway.NewQueryCache(time.Minute, 0).
+			Method(cpaperservice.CPaperChaincode_GetByExternalId, gateway.CacheMethod{Disabled: true}).
+			Method(cpaperservice.CPaperChaincode_List, gateway.CacheMethod{TTL: time.Millisecond})
+		ccService, cpaper := newCPaper(cache)
+
+		_, err := cpaper.Issue(ctx, issue(`0001`))
+		Expect(err).NotTo(HaveOccurred())
+
+		for i := 0; i < 2; i++ {
+			_, err = cpaper.GetByExternalId(ctx, &schema.ExternalId{Id: `EXT0001`})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(ccService.Queries()).To(Equal(2))
+		Expect(cache.Stats()).To(Equal(gateway.CacheStats{}))
+
+		_, err = cpaper.List(ctx, &empty.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		time.Sleep(5 * time.Millisecond)
+		_, err = cpaper.List(ctx, &empty.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		// expired
+		Expect(ccService.Queries()).To(Equal(4))
+		Expect(cache.Stats().Misses).To(Equal(uint64(2)))
+	})
+
+	It(`Allow to limit cache size`, func() {
+		cache := gateway.NewQueryCache(0, 1)
+		ccService, cpaper := newCPaper(cache)
+
+		for _, number := range []string{`0001`, `0002`} {
+			_, err := cpaper.Issue(ctx, issue(number))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cpaper.Get(ctx, paperId(number))
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		// least recently used result is evicted
+		_, err := cpaper.Get(ctx, paperId(`0001`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ccService.Queries()).To(Equal(3))
+		Expect(cache.Stats()).To(Equal(gateway.CacheStats{Misses: 3, Evictions: 2, Size: 1}))
+	})
+})
@@ -5,7 +5,11 @@ import (
 
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/optherium/cckit/convert"
+	ccerrors "github.com/optherium/cckit/errors"
+	"github.com/optherium/cckit/extensions/idempotency"
 	"github.com/optherium/cckit/gateway/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Action string
@@ -19,13 +23,27 @@ const (
 type Chaincode interface {
 	Query(ctx context.Context, fn string, args []interface{}, target interface{}) (interface{}, error)
 	Invoke(ctx context.Context, fn string, args []interface{}, target interface{}) (interface{}, error)
-	Events(ctx context.Context) (ChaincodeEventSub, error)
+	// InvokeWithResult returns transaction id and endorsement payload without waiting for commit
+	InvokeWithResult(ctx context.Context, fn string, args []interface{}, target interface{}) (*InvokeResult, error)
+	// Submit sends invoke to orderer, returns transaction id
+	Submit(ctx context.Context, fn string, args []interface{}) (string, error)
+	// WaitTx waits for transaction commit
+	WaitTx(ctx context.Context, txId string) (*service.ChaincodeTxStatus, error)
+	// Events returns live events subscription, with opts - events stream with filters, block range and checkpoint
+	Events(ctx context.Context, opts ...EventsOpt) (ChaincodeEventSub, error)
 }
 
 type ChaincodeEventSub interface {
 	Context() context.Context
 	Events() <-chan *peer.ChaincodeEvent
+	// TypedEvents returns events with payloads, decoded to types registered with WithEventTypes.
+	// Subscription is consumed either by Events or by TypedEvents, the other one returns closed channel
+	TypedEvents() <-chan *TypedEvent
 	Recv(*peer.ChaincodeEvent) error
+	// Checkpoint returns position of last received event, subscription can be resumed after it with WithCheckpoint
+	Checkpoint() *service.ChaincodeEventCheckpoint
+	// Err returns error, subscription is ended with by service
+	Err() error
 	Close()
 }
 
@@ -37,14 +55,21 @@ type chaincode struct {
 	ContextOpts []ContextOpt
 	InputOpts   []InputOpt
 	OutputOpts  []OutputOpt
-	EventOpts   []EventOpt
+	EventsConfig
+
+	Retry     *RetryPolicy
+	Cache     *QueryCache
+	RequestId RequestIdFunc
+
+	SignerResolver SignerResolver
 }
 
 func NewChaincode(service service.Chaincode, channelName, chaincodeName string, opts ...Opt) *chaincode {
 	c := &chaincode{
-		Service:   service,
-		Channel:   channelName,
-		Chaincode: chaincodeName,
+		Service:      service,
+		Channel:      channelName,
+		Chaincode:    chaincodeName,
+		EventsConfig: EventsConfig{EventsBuffer: DefaultEventsBuffer()},
 	}
 
 	for _, opt := range opts {
@@ -54,25 +79,65 @@ func NewChaincode(service service.Chaincode, channelName, chaincodeName string,
 	return c
 }
 
-func (g *chaincode) Events(ctx context.Context) (ChaincodeEventSub, error) {
-	stream := NewChaincodeEventServerStream(ctx, g.EventOpts...)
+func (g *chaincode) Events(ctx context.Context, opts ...EventsOpt) (ChaincodeEventSub, error) {
+	ctx, err := g.context(ctx)
+	if err != nil {
+		return nil, err
+	}
 
+	if len(opts) > 0 {
+		return g.eventsStream(ctx, opts...)
+	}
+
+	stream := g.EventsConfig.Stream(ctx)
 	go func() {
-		err := g.Service.Events(&service.ChaincodeLocator{
+		stream.Finish(g.Service.Events(&service.ChaincodeLocator{
 			Channel:   g.Channel,
 			Chaincode: g.Chaincode,
-		}, &service.ChaincodeEventsServer{ServerStream: stream})
+		}, &service.ChaincodeEventsServer{ServerStream: stream}))
+	}()
 
-		if err != nil {
-			stream.Close()
-		}
+	return stream, nil
+}
+
+func (g *chaincode) eventsStream(ctx context.Context, opts ...EventsOpt) (ChaincodeEventSub, error) {
+	req, err := eventsStreamRequest(g.Channel, g.Chaincode, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := g.EventsConfig.Stream(ctx)
+	go func() {
+		stream.Finish(g.Service.EventsStream(req, &service.ChaincodeEventsStreamServer{ServerStream: stream}))
 	}()
 
 	return stream, nil
 }
 
+func eventsStreamRequest(channel, chaincode string, opts ...EventsOpt) (*service.ChaincodeEventsStreamRequest, error) {
+	req := &service.ChaincodeEventsStreamRequest{
+		Channel:   channel,
+		Chaincode: chaincode,
+	}
+	for _, o := range opts {
+		o(req)
+	}
+	// check filters before subscription
+	if _, err := service.NewEventsStreamFilter(req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 func (g *chaincode) Query(ctx context.Context, fn string, args []interface{}, target interface{}) (interface{}, error) {
-	c := g.context(ctx)
+	c, err := g.context(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if g.Cache != nil && g.Cache.enabled(fn) {
+		return g.cachedQuery(c, fn, args, target)
+	}
+
 	ccInput, err := g.ccInput(c, Query, fn, args)
 	if err != nil {
 		return nil, err
@@ -85,8 +150,50 @@ func (g *chaincode) Query(ctx context.Context, fn string, args []interface{}, ta
 	}
 }
 
+// cachedQuery returns result from cache or queries chaincode and caches response payload, error responses
+// are not cached. Every call decodes payload to new target, so cached payload is not shared
+func (g *chaincode) cachedQuery(ctx context.Context, fn string, args []interface{}, target interface{}) (interface{}, error) {
+	argsBytes, err := convert.ArgsToBytes(args...)
+	if err != nil {
+		return nil, err
+	}
+	key, err := queryCacheKey(ctx, g.Channel, g.Chaincode, fn, argsBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// epoch is captured before query, so result of query, started before invalidation, is not cached
+	payload, epoch, ok := g.Cache.get(key)
+	if ok {
+		return convert.FromBytes(payload, target)
+	}
+
+	ccInput, err := g.ccInput(ctx, Query, fn, args)
+	if err != nil {
+		return nil, err
+	}
+	response, err := g.Service.Query(ctx, ccInput)
+	if err != nil {
+		return nil, err
+	}
+	// output opts are applied to response payload in place
+	res, err := g.ccOutput(ctx, Query, response.Response, target)
+	if err != nil {
+		return nil, err
+	}
+	g.Cache.put(key, epoch, g.Chaincode, fn, response.Response.Payload)
+	return res, nil
+}
+
 func (g *chaincode) Invoke(ctx context.Context, fn string, args []interface{}, target interface{}) (interface{}, error) {
-	c := g.context(ctx)
+	if g.Retry != nil {
+		return g.invokeWithRetry(ctx, fn, args, target)
+	}
+
+	c, err := g.context(ctx)
+	if err != nil {
+		return nil, err
+	}
 	ccInput, err := g.ccInput(c, Invoke, fn, args)
 	if err != nil {
 		return nil, err
@@ -99,11 +206,20 @@ func (g *chaincode) Invoke(ctx context.Context, fn string, args []interface{}, t
 	}
 }
 
-func (g *chaincode) context(ctx context.Context) context.Context {
+// context applies context opts, with signer resolver sets resolved signer or rejects request
+func (g *chaincode) context(ctx context.Context) (context.Context, error) {
 	for _, c := range g.ContextOpts {
 		ctx = c(ctx)
 	}
-	return ctx
+
+	if g.SignerResolver != nil {
+		signer, err := g.SignerResolver.Resolve(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, `%s: %s`, ErrSignerNotResolved, err)
+		}
+		ctx = service.ContextWithSigner(ctx, signer)
+	}
+	return ctx, nil
 }
 
 func (g *chaincode) ccInput(ctx context.Context, action Action, fn string, args []interface{}) (ccInput *service.ChaincodeInput, err error) {
@@ -121,6 +237,11 @@ func (g *chaincode) ccInput(ctx context.Context, action Action, fn string, args
 	if ccInput.Transient, err = TransientFromContext(ctx); err != nil {
 		return nil, err
 	}
+	if action == Invoke && g.RequestId != nil {
+		if ccInput.Transient, err = g.withRequestId(ctx, ccInput.Transient, fn, args); err != nil {
+			return nil, err
+		}
+	}
 
 	for _, i := range g.InputOpts {
 		if err = i(action, ccInput); err != nil {
@@ -131,7 +252,32 @@ func (g *chaincode) ccInput(ctx context.Context, action Action, fn string, args
 	return
 }
 
+// withRequestId returns copy of transient map with request id, if request id is not set by caller
+func (g *chaincode) withRequestId(
+	ctx context.Context, transient map[string][]byte, fn string, args []interface{}) (map[string][]byte, error) {
+	if _, ok := transient[idempotency.TransientMapKey]; ok {
+		return transient, nil
+	}
+
+	requestId, err := g.RequestId(ctx, fn, args)
+	if err != nil || requestId == `` {
+		return transient, err
+	}
+
+	withRequestId := make(map[string][]byte, len(transient)+1)
+	for k, v := range transient {
+		withRequestId[k] = v
+	}
+	withRequestId[idempotency.TransientMapKey] = []byte(requestId)
+	return withRequestId, nil
+}
+
 func (g *chaincode) ccOutput(ctx context.Context, action Action, response *peer.Response, target interface{}) (res interface{}, err error) {
+	// decode typed error from error response payload
+	if err = ccerrors.FromResponse(*response); err != nil {
+		return nil, err
+	}
+
 	for _, o := range g.OutputOpts {
 		if err = o(action, response); err != nil {
 			return nil, err
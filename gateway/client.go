@@ -1,40 +1,140 @@
 package gateway
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/hyperledger/fabric/core/chaincode/shim"
-	"github.com/optherium/cckit/state"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/optherium/cckit/gateway/service"
 )
 
+// ChaincodeClient for calling another chaincode from chaincode via stub.InvokeChaincode
 type ChaincodeClient interface {
 	Query(stub shim.ChaincodeStubInterface, fn string, args []interface{}, target interface{}) (interface{}, error)
 }
 
-type ClientOpt func(*chaincodeClient)
+// ChaincodeInvokeClient for calling another chaincode from chaincode, including methods changing state
+type ChaincodeInvokeClient interface {
+	ChaincodeClient
+	Invoke(stub shim.ChaincodeStubInterface, fn string, args []interface{}, target interface{}) (interface{}, error)
+}
+
+// TransientChaincodeInvoker invokes chaincode with transient map, other than tx transient map (i.e. testing.MockStub).
+// Peer passes signed proposal of current tx to called chaincode, so peer stub can't forward other transient values
+type TransientChaincodeInvoker interface {
+	InvokeChaincodeWithTransient(chaincodeName string, args [][]byte, channel string, transient map[string][]byte) peer.Response
+}
+
+// ClientOpt chaincode client option
+// Deprecated: use Opt, chaincode client accepts the same options as NewChaincode
+type ClientOpt = Opt
 
 type chaincodeClient struct {
 	Channel   string
 	Chaincode string
+	Opts      []Opt
 }
 
+// stubService implements chaincode service via stub.InvokeChaincode,
+// called chaincode receives transient map of current tx with transient values from options
+type stubService struct {
+	stub shim.ChaincodeStubInterface
+}
+
+// NewChaincodeClient creates client for calling chaincode from another chaincode.
+// Same options as for NewChaincode (i.e. WithEncryption) can be used, called chaincode receives
+// transient map of current tx with transient values from options (WithTransientValue). Values, not in tx transient map,
+// can be forwarded only by stub, implementing TransientChaincodeInvoker, otherwise ErrTransientNotPassed is returned
 func NewChaincodeClient(channelName, chaincodeName string, opts ...Opt) *chaincodeClient {
-	c := &chaincodeClient{
+	return &chaincodeClient{
 		Channel:   channelName,
 		Chaincode: chaincodeName,
+		Opts:      opts,
 	}
-
-	return c
 }
 
 func (c *chaincodeClient) Query(stub shim.ChaincodeStubInterface, fn string, args []interface{}, target interface{}) (interface{}, error) {
+	return c.chaincode(stub).Query(context.Background(), fn, args, target)
+}
+
+func (c *chaincodeClient) Invoke(stub shim.ChaincodeStubInterface, fn string, args []interface{}, target interface{}) (interface{}, error) {
+	return c.chaincode(stub).Invoke(context.Background(), fn, args, target)
+}
+
+func (c *chaincodeClient) chaincode(stub shim.ChaincodeStubInterface) *chaincode {
+	return NewChaincode(&stubService{stub: stub}, c.Channel, c.Chaincode, c.Opts...)
+}
+
+func (s *stubService) Query(ctx context.Context, in *service.ChaincodeInput) (*peer.ProposalResponse, error) {
+	return s.invoke(in)
+}
+
+func (s *stubService) Invoke(ctx context.Context, in *service.ChaincodeInput) (*peer.ProposalResponse, error) {
+	return s.invoke(in)
+}
 
-	// if target chaincode is encrypted we only can invoke `stateGet` function
-	// for example < encrypted(`orgGet`),  encrypted( &schema.OrganizationId { Id : `123` }) > will be <  `stateGet`, []string { key } >
-	// we know target ( &schema.Organization ), know input parameter type ( &schema.OrganizationId )
-	// if target has primary key with this type or uniq key with this type - we create state,Ke
-	//if 1==2 {
-	//	fn = `stateGet`
-	//
-	//}
+// Submit invokes chaincode within current tx
+func (s *stubService) Submit(ctx context.Context, in *service.ChaincodeInput) (*service.ChaincodeSubmitResponse, error) {
+	response, err := s.invoke(in)
+	if err != nil {
+		return nil, err
+	}
+	return &service.ChaincodeSubmitResponse{TxId: s.stub.GetTxID(), Response: response}, nil
+}
 
-	return state.InvokeChaincode(stub, c.Chaincode, append([]interface{}{fn}, args...), c.Channel, target)
+func (s *stubService) TxStatus(context.Context, *service.ChaincodeTxLocator) (*service.ChaincodeTxStatus, error) {
+	return nil, ErrTxStatusNotSupported
+}
+
+func (s *stubService) Events(*service.ChaincodeLocator, service.Chaincode_EventsServer) error {
+	return ErrEventsNotSupported
+}
+
+func (s *stubService) EventsStream(*service.ChaincodeEventsStreamRequest, service.Chaincode_EventsStreamServer) error {
+	return ErrEventsNotSupported
+}
+
+func (s *stubService) invoke(in *service.ChaincodeInput) (*peer.ProposalResponse, error) {
+	transient, forwarded, err := s.transient(in.Transient)
+	if err != nil {
+		return nil, err
+	}
+
+	var response peer.Response
+	switch invoker, ok := s.stub.(TransientChaincodeInvoker); {
+	case len(forwarded) == 0:
+		response = s.stub.InvokeChaincode(in.Chaincode, in.Args, in.Channel)
+	case ok:
+		response = invoker.InvokeChaincodeWithTransient(in.Chaincode, in.Args, in.Channel, transient)
+	default:
+		return nil, fmt.Errorf(`%s: %s`, ErrTransientNotPassed, strings.Join(forwarded, `, `))
+	}
+	return &peer.ProposalResponse{Response: &response}, nil
+}
+
+// transient returns current tx transient map with values from input and sorted keys of values,
+// which are not in tx transient map
+func (s *stubService) transient(values map[string][]byte) (map[string][]byte, []string, error) {
+	txTransient, err := s.stub.GetTransient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var forwarded []string
+	transient := make(map[string][]byte, len(txTransient)+len(values))
+	for key, value := range txTransient {
+		transient[key] = value
+	}
+	for key, value := range values {
+		if !bytes.Equal(txTransient[key], value) {
+			forwarded = append(forwarded, key)
+		}
+		transient[key] = value
+	}
+	sort.Strings(forwarded)
+	return transient, forwarded, nil
 }
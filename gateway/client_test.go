@@ -0,0 +1,130 @@
+package gateway_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/optherium/cckit/convert"
+	"github.com/optherium/cckit/errors"
+	"github.com/optherium/cckit/extensions/encryption"
+	"github.com/optherium/cckit/gateway"
+	"github.com/optherium/cckit/router"
+	"github.com/optherium/cckit/router/param"
+	testcc "github.com/optherium/cckit/testing"
+	expectcc "github.com/optherium/cckit/testing/expect"
+)
+
+func TestGateway(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Gateway suite")
+}
+
+var ErrNotAllowed = errors.NewCCError(403, `NOT_ALLOWED`, `not allowed`)
+
+// NewCallee creates chaincode, called from another chaincode
+func NewCallee() *router.Chaincode {
+	r := router.New(`callee`).
+		Pre(encryption.ArgsDecryptIfKeyProvided).
+		Init(router.EmptyContextHandler).
+		Invoke(`put`, func(c router.Context) (interface{}, error) {
+			value := c.ParamString(`value`)
+			if err := c.State().Put(`value`, value); err != nil {
+				return nil, err
+			}
+			if key, _ := encryption.KeyFromTransient(c); key != nil {
+				return encryption.Encrypt(key, value)
+			}
+			return value, nil
+		}, param.String(`value`)).
+		Query(`get`, func(c router.Context) (interface{}, error) {
+			return c.State().Get(`value`, convert.TypeString)
+		}).
+		Invoke(`fail`, func(c router.Context) (interface{}, error) {
+			return nil, ErrNotAllowed.WithDetail(`reason`, `test`)
+		})
+
+	return router.NewChaincode(r)
+}
+
+// peerStub hides MockStub.InvokeChaincodeWithTransient, like peer stub can't forward transient values
+type peerStub struct {
+	shim.ChaincodeStubInterface
+}
+
+// NewCaller creates chaincode, calling callee with chaincode client
+func NewCaller(client gateway.ChaincodeInvokeClient) *router.Chaincode {
+	r := router.New(`caller`).
+		Init(router.EmptyContextHandler).
+		Invoke(`put`, func(c router.Context) (interface{}, error) {
+			return client.Invoke(c.Stub(), `put`, []interface{}{c.ParamString(`value`)}, convert.TypeString)
+		}, param.String(`value`)).
+		Invoke(`putFromPeer`, func(c router.Context) (interface{}, error) {
+			return client.Invoke(&peerStub{c.Stub()}, `put`, []interface{}{c.ParamString(`value`)}, convert.TypeString)
+		}, param.String(`value`)).
+		Query(`get`, func(c router.Context) (interface{}, error) {
+			return client.Query(c.Stub(), `get`, nil, convert.TypeString)
+		}).
+		Invoke(`fail`, func(c router.Context) (interface{}, error) {
+			return client.Invoke(c.Stub(), `fail`, nil, nil)
+		})
+
+	return router.NewChaincode(r)
+}
+
+var _ = Describe(`Chaincode client`, func() {
+
+	encKey := make([]byte, 32)
+	_, _ = rand.Read(encKey)
+
+	callee := testcc.NewMockStub(`callee`, NewCallee())
+	caller := testcc.NewMockStub(`caller`, NewCaller(gateway.NewChaincodeClient(``, `callee`)))
+	caller.MockPeerChaincode(`callee`, callee)
+
+	encCaller := testcc.NewMockStub(`encCaller`, NewCaller(
+		gateway.NewChaincodeClient(``, `callee`, gateway.WithEncryption(encKey))))
+	encCaller.MockPeerChaincode(`callee`, callee)
+
+	It(`Allow to invoke and query another chaincode`, func() {
+		expectcc.PayloadString(caller.Invoke(`put`, `abc`), `abc`)
+		expectcc.PayloadString(caller.Query(`get`), `abc`)
+	})
+
+	It(`Allow to decode typed error from called chaincode`, func() {
+		ccErr := expectcc.ResponseErrorIs(caller.Invoke(`fail`), ErrNotAllowed)
+		Expect(ccErr.Code).To(Equal(int32(403)))
+		Expect(ccErr.Details[`reason`]).To(Equal(`test`))
+	})
+
+	It(`Allow to encrypt args and decrypt response with key from tx transient map`, func() {
+		expectcc.PayloadString(
+			encCaller.WithTransient(encryption.TransientMapWithKey(encKey)).Invoke(`put`, `def`), `def`)
+		expectcc.PayloadString(caller.Query(`get`), `def`)
+	})
+
+	It(`Allow to forward transient values, not in tx transient map`, func() {
+		expectcc.PayloadString(encCaller.Invoke(`put`, `ghi`), `ghi`)
+		expectcc.PayloadString(caller.Query(`get`), `ghi`)
+	})
+
+	It(`Restore creator and transient map of called chaincode after call`, func() {
+		keepingCallee := testcc.NewMockStub(`callee`, NewCallee())
+		keepingCallee.ClearCreatorAfterInvoke = false
+		keepingCallee.WithTransient(map[string][]byte{`key`: []byte(`value`)})
+
+		keepingCaller := testcc.NewMockStub(`caller`, NewCaller(gateway.NewChaincodeClient(``, `callee`)))
+		keepingCaller.MockPeerChaincode(`callee`, keepingCallee)
+
+		expectcc.PayloadString(keepingCaller.WithTransient(map[string][]byte{`other`: []byte(`value`)}).Invoke(`put`, `xyz`), `xyz`)
+		Expect(keepingCallee.GetTransient()).To(Equal(map[string][]byte{`key`: []byte(`value`)}))
+	})
+
+	It(`Disallow to forward transient values with stub, not supporting it`, func() {
+		expectcc.PayloadString(
+			encCaller.WithTransient(encryption.TransientMapWithKey(encKey)).Invoke(`putFromPeer`, `jkl`), `jkl`)
+		expectcc.ResponseError(encCaller.Invoke(`putFromPeer`, `jkl`), gateway.ErrTransientNotPassed)
+	})
+})
@@ -2,6 +2,8 @@ package gateway
 
 import (
 	"context"
+
+	"github.com/optherium/cckit/extensions/idempotency"
 )
 
 const CtxTransientKey = `TransientMap`
@@ -26,3 +28,15 @@ func TransientFromContext(ctx context.Context) (map[string][]byte, error) {
 		return transient, nil
 	}
 }
+
+// ContextWithRequestId sets request id for invoke, used by chaincode idempotency middleware for deduplication.
+// Transient map of parent context is copied, so request id is not shared with other calls
+func ContextWithRequestId(ctx context.Context, requestId string) context.Context {
+	parent, _ := ctx.Value(CtxTransientKey).(map[string][]byte)
+	transient := make(map[string][]byte, len(parent)+1)
+	for k, v := range parent {
+		transient[k] = v
+	}
+	transient[idempotency.TransientMapKey] = []byte(requestId)
+	return ContextWithTransientMap(ctx, transient)
+}
@@ -4,4 +4,41 @@ import "errors"
 
 var (
 	ErrEventChannelClosed = errors.New(`event channel is closed`)
+
+	// ErrEventsBufferOverflow occurs when events buffer is full and OverflowError policy is used
+	ErrEventsBufferOverflow = errors.New(`events buffer overflow`)
+
+	// ErrEventTypeUnknown occurs when event stream message is neither peer.ChaincodeEvent nor service.ChaincodeEvent
+	ErrEventTypeUnknown = errors.New(`event type unknown`)
+
+	// ErrEventPayloadDecode occurs when event payload can't be decoded to registered event type
+	ErrEventPayloadDecode = errors.New(`event payload decode`)
+
+	// ErrEventsNotSupported occurs when events are requested from chaincode client, used inside chaincode
+	ErrEventsNotSupported = errors.New(`events not supported`)
+
+	// ErrTransientNotPassed occurs when transient value for chaincode call from chaincode is not in tx transient map
+	ErrTransientNotPassed = errors.New(`transient value not in tx transient map`)
+
+	// ErrRetrySubmitterNotDefined occurs when invoke with retry policy (WithRetry) is called with chaincode service,
+	// which can't submit transactions (i.e. service.ChaincodeService created without service.WithSubmitter)
+	ErrRetrySubmitterNotDefined = errors.New(`retry requires chaincode service with submitter`)
+
+	// ErrTxInvalid occurs when committed transaction has validation code other than VALID
+	ErrTxInvalid = errors.New(`transaction invalid`)
+
+	// ErrTxStatusNotSupported occurs when transaction status is requested from chaincode client, used inside chaincode
+	ErrTxStatusNotSupported = errors.New(`tx status not supported`)
+
+	// ErrSignerNotResolved occurs when signer resolver can't resolve signing identity of caller
+	ErrSignerNotResolved = errors.New(`signer not resolved`)
+
+	// ErrCallerIdNotFound occurs when request doesn't contain caller credentials
+	ErrCallerIdNotFound = errors.New(`caller id not found`)
+
+	// ErrIdentityNotFound occurs when identity store doesn't contain identity for caller id
+	ErrIdentityNotFound = errors.New(`identity not found`)
+
+	// ErrTokenInvalid occurs when bearer token is malformed, wrongly signed, expired or has no expiration time
+	ErrTokenInvalid = errors.New(`token invalid`)
 )
@@ -6,28 +6,135 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/optherium/cckit/gateway/service"
 	"google.golang.org/grpc/metadata"
 )
 
+// OverflowPolicy defines SendMsg behaviour when events buffer is full
+type OverflowPolicy int
+
+const (
+	// OverflowBlock blocks producer until consumer receives event or stream is closed
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest removes oldest buffered event to make room for new one
+	OverflowDropOldest
+	// OverflowError fails stream with ErrEventsBufferOverflow
+	OverflowError
+)
+
+const (
+	// DefaultEventsBufferSize number of events, buffered by subscription if buffer size is not set with WithEventsBuffer
+	DefaultEventsBufferSize = 100
+	// DefaultEventsOverflow overflow policy of subscription if not set with WithEventsBuffer
+	DefaultEventsOverflow = OverflowBlock
+)
+
+// EventsConfig events processing of subscription: event opts (i.e. decryption), payload types, decoded by
+// TypedEvents, and events buffer
+type EventsConfig struct {
+	EventOpts    []EventOpt
+	EventTypes   EventTypes
+	EventsBuffer EventsBuffer
+}
+
+// NewEventsConfig returns events config with default events buffer, changed by event opts, event types and
+// events buffer options (WithEventDecryption, WithEventTypes, WithEventsBuffer etc), other options are ignored
+func NewEventsConfig(opts ...Opt) EventsConfig {
+	c := &chaincode{EventsConfig: EventsConfig{EventsBuffer: DefaultEventsBuffer()}}
+	for _, o := range opts {
+		o(c)
+	}
+	return c.EventsConfig
+}
+
+// Stream creates events stream with config
+func (c EventsConfig) Stream(ctx context.Context) *ChaincodeEventServerStream {
+	stream := NewBufferedChaincodeEventServerStream(ctx, c.EventsBuffer, c.EventOpts...)
+	stream.types = c.EventTypes
+	return stream
+}
+
+// EventsBuffer size of subscription events buffer and policy, applied when buffer is full
+type EventsBuffer struct {
+	Size     int
+	Overflow OverflowPolicy
+}
+
+// DefaultEventsBuffer returns events buffer with default size and overflow policy
+func DefaultEventsBuffer() EventsBuffer {
+	return EventsBuffer{Size: DefaultEventsBufferSize, Overflow: DefaultEventsOverflow}
+}
+
+type streamConsumer int
+
+const (
+	consumerNone streamConsumer = iota
+	consumerEvents
+	consumerTypedEvents
+)
+
+// ChaincodeEventServerStream receives chaincode events from service, events with checkpoints
+// are received from EventsStream, events without checkpoints - from Events.
+// Stream is closed by consumer with Close, producer ends stream with Finish - buffered events
+// are still delivered, then Recv returns producer error or ErrEventChannelClosed
 type ChaincodeEventServerStream struct {
-	context context.Context
-	events  chan *peer.ChaincodeEvent
-	ready   bool
-	opts    []EventOpt
-	once    sync.Once
+	context  context.Context
+	events   chan *service.ChaincodeEvent
+	overflow OverflowPolicy
+	opts     []EventOpt
+
+	// closed by consumer or context
+	closed    chan struct{}
+	closeOnce sync.Once
+	// finished by producer
+	finished   chan struct{}
+	finishOnce sync.Once
+
+	// serializes producers, so dropping oldest event keeps order
+	send sync.Mutex
+
+	out     chan *peer.ChaincodeEvent
+	outOnce sync.Once
+
+	types     EventTypes
+	typed     <-chan *TypedEvent
+	typedOnce sync.Once
+
+	// Events or TypedEvents, the first called, consumes stream
+	consumer streamConsumer
+
+	m          sync.Mutex
+	err        error
+	dropped    uint64
+	checkpoint *service.ChaincodeEventCheckpoint
+}
+
+func NewChaincodeEventServerStream(ctx context.Context, opts ...EventOpt) *ChaincodeEventServerStream {
+	return NewBufferedChaincodeEventServerStream(ctx, DefaultEventsBuffer(), opts...)
 }
 
-func NewChaincodeEventServerStream(ctx context.Context, opts ...EventOpt) (stream *ChaincodeEventServerStream) {
+func NewBufferedChaincodeEventServerStream(
+	ctx context.Context, buffer EventsBuffer, opts ...EventOpt) (stream *ChaincodeEventServerStream) {
+
+	if buffer.Size < 1 {
+		buffer.Size = 1
+	}
+
 	stream = &ChaincodeEventServerStream{
-		context: ctx,
-		events:  make(chan *peer.ChaincodeEvent),
-		ready:   true,
-		opts:    opts,
+		context:  ctx,
+		events:   make(chan *service.ChaincodeEvent, buffer.Size),
+		overflow: buffer.Overflow,
+		opts:     opts,
+		closed:   make(chan struct{}),
+		finished: make(chan struct{}),
 	}
 
 	go func() {
-		<-ctx.Done()
-		stream.Close()
+		select {
+		case <-ctx.Done():
+			stream.Close()
+		case <-stream.closed:
+		}
 	}()
 
 	return stream
@@ -48,41 +155,248 @@ func (s *ChaincodeEventServerStream) Context() context.Context {
 	return s.context
 }
 
+// SendMsg accepts *peer.ChaincodeEvent or *service.ChaincodeEvent, when buffer is full acts according to overflow policy
 func (s *ChaincodeEventServerStream) SendMsg(m interface{}) (err error) {
-	if !s.ready {
-		return ErrEventChannelClosed
+	var e *service.ChaincodeEvent
+	switch msg := m.(type) {
+	case *peer.ChaincodeEvent:
+		e = &service.ChaincodeEvent{Event: proto.Clone(msg).(*peer.ChaincodeEvent)}
+	case *service.ChaincodeEvent:
+		e = proto.Clone(msg).(*service.ChaincodeEvent)
+	default:
+		return ErrEventTypeUnknown
+	}
+	if e.Event == nil {
+		return ErrEventTypeUnknown
 	}
 
-	e := proto.Clone(m.(*peer.ChaincodeEvent)).(*peer.ChaincodeEvent)
 	for _, o := range s.opts {
-		if err = o(e); err != nil {
+		if err = o(e.Event); err != nil {
 			return err
 		}
 	}
 
-	s.events <- e
-	return nil
+	s.send.Lock()
+	defer s.send.Unlock()
+
+	select {
+	case <-s.closed:
+		return ErrEventChannelClosed
+	case <-s.finished:
+		return ErrEventChannelClosed
+	default:
+	}
+
+	switch s.overflow {
+	case OverflowDropOldest:
+		for {
+			select {
+			case s.events <- e:
+				return nil
+			default:
+			}
+			select {
+			case <-s.events:
+				s.m.Lock()
+				s.dropped++
+				s.m.Unlock()
+			default:
+			}
+		}
+
+	case OverflowError:
+		select {
+		case s.events <- e:
+			return nil
+		default:
+			s.Finish(ErrEventsBufferOverflow)
+			return ErrEventsBufferOverflow
+		}
+
+	default:
+		select {
+		case s.events <- e:
+			return nil
+		case <-s.closed:
+			return ErrEventChannelClosed
+		case <-s.finished:
+			return ErrEventChannelClosed
+		}
+	}
 }
 
 func (s *ChaincodeEventServerStream) Recv(e *peer.ChaincodeEvent) error {
 	return s.RecvMsg(e)
 }
 
+// RecvMsg copies next event to *peer.ChaincodeEvent or *service.ChaincodeEvent.
+// After stream is finished and buffer drained returns stream error or ErrEventChannelClosed
 func (s *ChaincodeEventServerStream) RecvMsg(m interface{}) error {
-	var ok bool
-	if m, ok = <-s.events; ok {
-		return nil
+	switch m.(type) {
+	case *peer.ChaincodeEvent, *service.ChaincodeEvent:
+	default:
+		return ErrEventTypeUnknown
+	}
+
+	e, err := s.next()
+	if err != nil {
+		return err
+	}
+
+	switch msg := m.(type) {
+	case *peer.ChaincodeEvent:
+		msg.Reset()
+		proto.Merge(msg, e.Event)
+	case *service.ChaincodeEvent:
+		msg.Reset()
+		proto.Merge(msg, e)
+	}
+
+	s.setCheckpoint(e.Checkpoint)
+	return nil
+}
+
+// next returns buffered event, waits for new one or returns error if stream is closed or finished
+func (s *ChaincodeEventServerStream) next() (*service.ChaincodeEvent, error) {
+	select {
+	case <-s.closed:
+		return nil, ErrEventChannelClosed
+	default:
+	}
+
+	select {
+	case e := <-s.events:
+		return e, nil
+	case <-s.closed:
+		return nil, ErrEventChannelClosed
+	case <-s.finished:
+		// producer doesn't send after finish, deliver rest of buffer
+		select {
+		case e := <-s.events:
+			return e, nil
+		default:
+			return nil, s.finishErr()
+		}
 	}
-	return ErrEventChannelClosed
 }
 
+// Events returns channel of events, checkpoint is updated after event is received from channel.
+// Channel is closed when stream is closed or finished, stream error is returned by Err.
+// Events and TypedEvents are mutually exclusive: if stream is consumed by TypedEvents, returns closed channel
 func (s *ChaincodeEventServerStream) Events() <-chan *peer.ChaincodeEvent {
-	return s.events
+	if !s.consumeBy(consumerEvents) {
+		out := make(chan *peer.ChaincodeEvent)
+		close(out)
+		return out
+	}
+	return s.eventsOut()
+}
+
+// consumeBy sets stream consumer, returns false if stream is already consumed by other one
+func (s *ChaincodeEventServerStream) consumeBy(consumer streamConsumer) bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.consumer == consumerNone {
+		s.consumer = consumer
+	}
+	return s.consumer == consumer
+}
+
+func (s *ChaincodeEventServerStream) eventsOut() <-chan *peer.ChaincodeEvent {
+	s.outOnce.Do(func() {
+		s.out = make(chan *peer.ChaincodeEvent)
+		go func() {
+			defer close(s.out)
+			for {
+				e, err := s.next()
+				if err != nil {
+					return
+				}
+				select {
+				case s.out <- e.Event:
+					s.setCheckpoint(e.Checkpoint)
+				case <-s.closed:
+					return
+				}
+			}
+		}()
+	})
+	return s.out
+}
+
+// TypedEvents returns channel of events, decoded to types registered with WithEventTypes, events of other types
+// are skipped. Event opts (i.e. decryption) are applied before decoding. On decode error stream is closed
+// and error is returned by Err. If stream is consumed by Events, returns closed channel
+func (s *ChaincodeEventServerStream) TypedEvents() <-chan *TypedEvent {
+	if !s.consumeBy(consumerTypedEvents) {
+		out := make(chan *TypedEvent)
+		close(out)
+		return out
+	}
+	s.typedOnce.Do(func() {
+		s.typed = decodeEvents(s.eventsOut(), s.types, s.closed, func(err error) {
+			s.m.Lock()
+			s.err = err
+			s.m.Unlock()
+			s.Close()
+		})
+	})
+	return s.typed
+}
+
+// Checkpoint returns position of last received event, stream can be resumed after it with WithCheckpoint.
+// Returns nil if no events with position received
+func (s *ChaincodeEventServerStream) Checkpoint() *service.ChaincodeEventCheckpoint {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.checkpoint
+}
+
+func (s *ChaincodeEventServerStream) setCheckpoint(checkpoint *service.ChaincodeEventCheckpoint) {
+	if checkpoint == nil {
+		return
+	}
+	s.m.Lock()
+	s.checkpoint = checkpoint
+	s.m.Unlock()
+}
+
+// Dropped returns number of events, dropped with OverflowDropOldest policy
+func (s *ChaincodeEventServerStream) Dropped() uint64 {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.dropped
+}
+
+// Err returns error, stream is finished with, nil if stream is active or finished without error
+func (s *ChaincodeEventServerStream) Err() error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.err
+}
+
+func (s *ChaincodeEventServerStream) finishErr() error {
+	if err := s.Err(); err != nil {
+		return err
+	}
+	return ErrEventChannelClosed
+}
+
+// Finish ends stream from producer side, buffered events remain available to consumer
+func (s *ChaincodeEventServerStream) Finish(err error) {
+	s.finishOnce.Do(func() {
+		s.m.Lock()
+		if s.err == nil {
+			s.err = err
+		}
+		s.m.Unlock()
+		close(s.finished)
+	})
 }
 
+// Close ends stream from consumer side, buffered events are discarded
 func (s *ChaincodeEventServerStream) Close() {
-	s.once.Do(func() {
-		close(s.events)
-		s.ready = false
+	s.closeOnce.Do(func() {
+		close(s.closed)
 	})
 }
@@ -0,0 +1,151 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hyperledger/fabric/protos/peer"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/optherium/cckit/gateway"
+	"github.com/optherium/cckit/gateway/service"
+)
+
+func event(name string) *peer.ChaincodeEvent {
+	return &peer.ChaincodeEvent{ChaincodeId: `cc`, EventName: name, Payload: []byte(name)}
+}
+
+func send(stream *gateway.ChaincodeEventServerStream, names ...string) {
+	for _, name := range names {
+		Expect(stream.SendMsg(event(name))).To(Succeed())
+	}
+}
+
+var _ = Describe(`Chaincode event server stream`, func() {
+
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	It(`Allow to send events to buffer without consumer`, func() {
+		stream := gateway.NewBufferedChaincodeEventServerStream(ctx, gateway.EventsBuffer{Size: 3})
+		send(stream, `A`, `B`, `C`)
+		Expect(recvNames(stream, 3)).To(Equal([]string{`A`, `B`, `C`}))
+	})
+
+	It(`Allow to receive copy of event`, func() {
+		stream := gateway.NewChaincodeEventServerStream(ctx)
+		Expect(stream.SendMsg(&service.ChaincodeEvent{
+			Event:      event(`A`),
+			Checkpoint: &service.ChaincodeEventCheckpoint{Block: 5, TxIndex: 1},
+		})).To(Succeed())
+		send(stream, `B`)
+
+		e := &service.ChaincodeEvent{}
+		Expect(stream.RecvMsg(e)).To(Succeed())
+		Expect(e.Event).To(Equal(event(`A`)))
+		Expect(e.Checkpoint).To(Equal(&service.ChaincodeEventCheckpoint{Block: 5, TxIndex: 1}))
+
+		pe := &peer.ChaincodeEvent{TxId: `previous`}
+		Expect(stream.Recv(pe)).To(Succeed())
+		Expect(pe).To(Equal(event(`B`)))
+		Expect(stream.Checkpoint()).To(Equal(&service.ChaincodeEventCheckpoint{Block: 5, TxIndex: 1}))
+
+		Expect(stream.RecvMsg(&peer.ChaincodeInput{})).To(MatchError(gateway.ErrEventTypeUnknown))
+	})
+
+	It(`Allow to block producer when buffer is full`, func() {
+		stream := gateway.NewBufferedChaincodeEventServerStream(ctx, gateway.EventsBuffer{Size: 1})
+		send(stream, `A`)
+
+		sent := make(chan error)
+		go func() { sent <- stream.SendMsg(event(`B`)) }()
+		Consistently(sent, 100*time.Millisecond).ShouldNot(Receive())
+
+		Expect(recvNames(stream, 1)).To(Equal([]string{`A`}))
+		Eventually(sent).Should(Receive(BeNil()))
+		Expect(recvNames(stream, 1)).To(Equal([]string{`B`}))
+	})
+
+	It(`Allow to drop oldest events when buffer is full`, func() {
+		stream := gateway.NewBufferedChaincodeEventServerStream(ctx,
+			gateway.EventsBuffer{Size: 2, Overflow: gateway.OverflowDropOldest})
+		send(stream, `A`, `B`, `C`, `D`)
+		Expect(stream.Dropped()).To(Equal(uint64(2)))
+		Expect(recvNames(stream, 2)).To(Equal([]string{`C`, `D`}))
+	})
+
+	It(`Allow to fail stream when buffer is full`, func() {
+		stream := gateway.NewBufferedChaincodeEventServerStream(ctx,
+			gateway.EventsBuffer{Size: 1, Overflow: gateway.OverflowError})
+		send(stream, `A`)
+		Expect(stream.SendMsg(event(`B`))).To(MatchError(gateway.ErrEventsBufferOverflow))
+
+		Expect(recvNames(stream, 1)).To(Equal([]string{`A`}))
+		Expect(stream.Recv(&peer.ChaincodeEvent{})).To(MatchError(gateway.ErrEventsBufferOverflow))
+		Expect(stream.Err()).To(MatchError(gateway.ErrEventsBufferOverflow))
+	})
+
+	It(`Allow to receive buffered events and error after stream finished`, func() {
+		stream := gateway.NewChaincodeEventServerStream(ctx)
+		send(stream, `A`, `B`)
+		streamErr := errors.New(`connection lost`)
+		stream.Finish(streamErr)
+
+		Expect(stream.SendMsg(event(`C`))).To(MatchError(gateway.ErrEventChannelClosed))
+
+		var names []string
+		for e := range stream.Events() {
+			names = append(names, e.EventName)
+		}
+		Expect(names).To(Equal([]string{`A`, `B`}))
+		Expect(stream.Err()).To(Equal(streamErr))
+		Expect(stream.Recv(&peer.ChaincodeEvent{})).To(Equal(streamErr))
+	})
+
+	It(`Allow to close stream with blocked producer`, func() {
+		stream := gateway.NewBufferedChaincodeEventServerStream(ctx, gateway.EventsBuffer{Size: 1})
+		send(stream, `A`)
+
+		sent := make(chan error)
+		go func() { sent <- stream.SendMsg(event(`B`)) }()
+		stream.Close()
+		stream.Close()
+
+		Eventually(sent).Should(Receive(MatchError(gateway.ErrEventChannelClosed)))
+		Expect(stream.Recv(&peer.ChaincodeEvent{})).To(MatchError(gateway.ErrEventChannelClosed))
+	})
+
+	It(`Allow to finish stream with blocked producer`, func() {
+		stream := gateway.NewBufferedChaincodeEventServerStream(ctx, gateway.EventsBuffer{Size: 1})
+		send(stream, `A`)
+
+		sent := make(chan error)
+		go func() { sent <- stream.SendMsg(event(`B`)) }()
+		stream.Finish(nil)
+
+		Eventually(sent).Should(Receive(MatchError(gateway.ErrEventChannelClosed)))
+		Expect(stream.Recv(&peer.ChaincodeEvent{})).To(Succeed())
+		Expect(stream.Recv(&peer.ChaincodeEvent{})).To(MatchError(gateway.ErrEventChannelClosed))
+	})
+
+	It(`Allow to close stream with context`, func() {
+		stream := gateway.NewChaincodeEventServerStream(ctx)
+		events := stream.Events()
+		cancel()
+
+		Eventually(events).Should(BeClosed())
+		Expect(stream.SendMsg(event(`A`))).To(MatchError(gateway.ErrEventChannelClosed))
+	})
+})
@@ -0,0 +1,104 @@
+package gateway
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/optherium/cckit/state/mapping"
+)
+
+type (
+	// TypedEvent chaincode event with payload, decoded to proto message
+	TypedEvent struct {
+		Name    string
+		TxId    string
+		Message proto.Message
+	}
+
+	// EventTypes chaincode event name -> proto message, event payload is decoded to
+	EventTypes map[string]proto.Message
+)
+
+// NewEventTypes registers event payload types with event names, same as default names of chaincode
+// event mappings (state/mapping) - type name without package, i.e. "IssueCommercialPaper"
+func NewEventTypes(payloads ...proto.Message) EventTypes {
+	types := make(EventTypes)
+	for _, p := range payloads {
+		types.Add(EventName(p), p)
+	}
+	return types
+}
+
+// EventTypesFromMappings registers proto payload types of chaincode event mappings with mapped event names,
+// so chaincode event mappings can be used off-chain. Mappings of not proto types are skipped
+func EventTypesFromMappings(mappings mapping.EventMappings) EventTypes {
+	types := make(EventTypes)
+	for _, m := range mappings {
+		payload, ok := m.Schema().(proto.Message)
+		if !ok {
+			continue
+		}
+		if name, err := m.Name(payload); err == nil {
+			types.Add(name, payload)
+		}
+	}
+	return types
+}
+
+// EventName returns default event name for payload type
+func EventName(payload interface{}) string {
+	t := reflect.TypeOf(payload).String()
+	return t[strings.Index(t, `.`)+1:]
+}
+
+// Add registers event payload type with event name
+func (et EventTypes) Add(name string, payload proto.Message) EventTypes {
+	et[name] = payload
+	return et
+}
+
+// Decode unmarshals event payload to new message of type, registered for event name.
+// Returns nil if type is not registered for event name
+func (et EventTypes) Decode(e *peer.ChaincodeEvent) (*TypedEvent, error) {
+	payloadType, ok := et[e.EventName]
+	if !ok {
+		return nil, nil
+	}
+
+	msg := proto.Clone(payloadType)
+	msg.Reset()
+	if err := proto.Unmarshal(e.Payload, msg); err != nil {
+		return nil, fmt.Errorf(`%s: %s: %s`, ErrEventPayloadDecode, e.EventName, err)
+	}
+	return &TypedEvent{Name: e.EventName, TxId: e.TxId, Message: msg}, nil
+}
+
+// decodeEvents decodes events of registered types until events channel is closed or done,
+// decode error is passed to fail
+func decodeEvents(
+	events <-chan *peer.ChaincodeEvent, types EventTypes, done <-chan struct{}, fail func(error)) <-chan *TypedEvent {
+
+	out := make(chan *TypedEvent)
+	go func() {
+		defer close(out)
+		for e := range events {
+			typed, err := types.Decode(e)
+			if err != nil {
+				fail(err)
+				return
+			}
+			if typed == nil {
+				continue
+			}
+			select {
+			case out <- typed:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,234 @@
+package gateway_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/peer"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/optherium/cckit/examples/payment"
+	paymentschema "github.com/optherium/cckit/examples/payment/schema"
+	"github.com/optherium/cckit/gateway"
+	"github.com/optherium/cckit/gateway/service"
+	"github.com/optherium/cckit/router"
+	"github.com/optherium/cckit/router/param"
+	testcc "github.com/optherium/cckit/testing"
+)
+
+// NewEmitter creates chaincode, setting event with name from args
+func NewEmitter() *router.Chaincode {
+	r := router.New(`emitter`).
+		Init(router.EmptyContextHandler).
+		Invoke(`emit`, func(c router.Context) (interface{}, error) {
+			name := c.ParamString(`name`)
+			return name, c.Event().Set(name, name)
+		}, param.String(`name`)).
+		Invoke(`noEvent`, func(c router.Context) (interface{}, error) {
+			return nil, nil
+		})
+
+	return router.NewChaincode(r)
+}
+
+// recvNames receives event names until stream closed or n events received
+func recvNames(sub gateway.ChaincodeEventSub, n int) []string {
+	var names []string
+	for len(names) < n {
+		e := &peer.ChaincodeEvent{}
+		if err := sub.Recv(e); err != nil {
+			break
+		}
+		names = append(names, e.EventName)
+	}
+	return names
+}
+
+var _ = Describe(`Chaincode events stream`, func() {
+
+	var (
+		emitter gateway.Chaincode
+		ctx     context.Context
+		cancel  context.CancelFunc
+	)
+
+	identity := testcc.MustGenerateIdentity(`MSP`, pkix.Name{CommonName: `emitter`}, nil)
+	ccService := service.NewMock().WithChannel(`events`, testcc.NewMockStub(`emitter`, NewEmitter()))
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	It(`Allow to record events of invokes via mock service`, func() {
+		emitter = gateway.NewChaincode(ccService, `events`, `emitter`, gateway.WithDefaultSigner(identity))
+		// blocks 1 - 5, block 3 without event
+		for _, name := range []string{`Created`, `Updated`} {
+			_, err := emitter.Invoke(ctx, `emit`, []interface{}{name}, ``)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		_, err := emitter.Invoke(ctx, `noEvent`, nil, ``)
+		Expect(err).NotTo(HaveOccurred())
+		for _, name := range []string{`Deleted`, `Created`} {
+			_, err := emitter.Invoke(ctx, `emit`, []interface{}{name}, ``)
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+
+	It(`Allow to replay events from block with name filter`, func() {
+		sub, err := emitter.Events(ctx, gateway.WithBlockRange(1, 0), gateway.WithEventNames(`Created`, `Deleted`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recvNames(sub, 3)).To(Equal([]string{`Created`, `Deleted`, `Created`}))
+	})
+
+	It(`Allow to filter events by name regexp`, func() {
+		sub, err := emitter.Events(ctx, gateway.WithBlockRange(1, 0), gateway.WithEventNameRegexps(`^Up`, `^Del`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recvNames(sub, 2)).To(Equal([]string{`Updated`, `Deleted`}))
+	})
+
+	It(`Allow to limit stream with block range`, func() {
+		sub, err := emitter.Events(ctx, gateway.WithBlockRange(2, 4))
+		Expect(err).NotTo(HaveOccurred())
+		// stream is closed after last block
+		Expect(recvNames(sub, 10)).To(Equal([]string{`Updated`, `Deleted`}))
+		Expect(sub.Checkpoint()).To(Equal(&service.ChaincodeEventCheckpoint{Block: 4}))
+	})
+
+	It(`Allow to resume stream after checkpoint without losing or repeating events`, func() {
+		sub, err := emitter.Events(ctx, gateway.WithBlockRange(1, 0))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recvNames(sub, 2)).To(Equal([]string{`Created`, `Updated`}))
+		checkpoint := sub.Checkpoint()
+		sub.Close()
+
+		resumed, err := emitter.Events(ctx, gateway.WithCheckpoint(checkpoint))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recvNames(resumed, 2)).To(Equal([]string{`Deleted`, `Created`}))
+	})
+
+	It(`Allow to stream new events`, func(done Done) {
+		sub, err := emitter.Events(ctx, gateway.WithEventNames(`Live`))
+		Expect(err).NotTo(HaveOccurred())
+
+		// subscription starts from newest block asynchronously, so emit until event received
+		stop := make(chan struct{})
+		go func() {
+			for {
+				select {
+				case <-stop:
+					return
+				case <-time.After(10 * time.Millisecond):
+					_, _ = emitter.Invoke(ctx, `emit`, []interface{}{`Created`}, ``)
+					_, _ = emitter.Invoke(ctx, `emit`, []interface{}{`Live`}, ``)
+				}
+			}
+		}()
+
+		e := <-sub.Events()
+		close(stop)
+		Expect(e.EventName).To(Equal(`Live`))
+		Expect(e.ChaincodeId).To(Equal(`emitter`))
+		close(done)
+	}, 1)
+
+	It(`Disallow invalid event name regexp`, func() {
+		_, err := emitter.Events(ctx, gateway.WithEventNameRegexps(`(`))
+		Expect(err).To(MatchError(ContainSubstring(service.ErrEventNameRegexpInvalid.Error())))
+	})
+})
+
+var _ = Describe(`Typed events`, func() {
+
+	types := gateway.NewEventTypes(&service.ChaincodeLocator{})
+
+	event := func(name string, payload []byte) *peer.ChaincodeEvent {
+		return &peer.ChaincodeEvent{EventName: name, TxId: `tx`, Payload: payload}
+	}
+
+	It(`Allow to register event types with default event names`, func() {
+		Expect(types).To(HaveKey(`ChaincodeLocator`))
+	})
+
+	It(`Allow to decode events of registered types and skip others`, func() {
+		payload, err := proto.Marshal(&service.ChaincodeLocator{Channel: `ch`, Chaincode: `cc`})
+		Expect(err).NotTo(HaveOccurred())
+
+		sub := gateway.NewEventsConfig(gateway.WithEventTypes(types)).Stream(context.Background())
+		Expect(sub.SendMsg(event(`Other`, []byte(`other`)))).To(Succeed())
+		Expect(sub.SendMsg(event(`ChaincodeLocator`, payload))).To(Succeed())
+		sub.Finish(nil)
+
+		var e *gateway.TypedEvent
+		Eventually(sub.TypedEvents()).Should(Receive(&e))
+		Expect(e.Name).To(Equal(`ChaincodeLocator`))
+		Expect(e.TxId).To(Equal(`tx`))
+		Expect(e.Message.(*service.ChaincodeLocator).Chaincode).To(Equal(`cc`))
+
+		Eventually(sub.TypedEvents()).Should(BeClosed())
+		Expect(sub.Err()).NotTo(HaveOccurred())
+	})
+
+	It(`Disallow to decode payload of other type`, func() {
+		sub := gateway.NewEventsConfig(gateway.WithEventTypes(types)).Stream(context.Background())
+		Expect(sub.SendMsg(event(`ChaincodeLocator`, []byte{0xff}))).To(Succeed())
+
+		Eventually(sub.TypedEvents()).Should(BeClosed())
+		Expect(sub.Err()).To(MatchError(ContainSubstring(gateway.ErrEventPayloadDecode.Error())))
+	})
+
+	It(`Disallow to consume subscription by both events and typed events`, func() {
+		sub := gateway.NewEventsConfig(gateway.WithEventTypes(types)).Stream(context.Background())
+		typed := sub.TypedEvents()
+		Eventually(sub.Events()).Should(BeClosed())
+
+		Expect(sub.SendMsg(event(`Other`, []byte(`other`)))).To(Succeed())
+		sub.Finish(nil)
+		Eventually(typed).Should(BeClosed())
+
+		other := gateway.NewChaincodeEventServerStream(context.Background())
+		Expect(other.Events()).NotTo(BeClosed())
+		Eventually(other.TypedEvents()).Should(BeClosed())
+		other.Close()
+	})
+
+	It(`Allow to register event types from chaincode event mappings`, func() {
+		Expect(gateway.EventTypesFromMappings(payment.EventMappings)).To(HaveKey(`PaymentEvent`))
+	})
+
+	It(`Allow to decode decrypted events with chaincode event mappings`, func() {
+		encKey := make([]byte, 32)
+		_, _ = rand.Read(encKey)
+
+		identity := testcc.MustGenerateIdentity(`MSP`, pkix.Name{CommonName: `payer`}, nil)
+		payments := gateway.NewChaincode(
+			service.NewMock().WithChannel(`payments`,
+				testcc.NewMockStub(`payments`, payment.NewEncryptedPaymentCCWithEncStateContext())),
+			`payments`, `payments`,
+			gateway.WithDefaultSigner(identity),
+			gateway.WithEncryption(encKey),
+			gateway.WithEventMappings(payment.EventMappings))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		_, err := payments.Invoke(ctx, `paymentCreate`, []interface{}{`SALE`, `1`, 100}, &paymentschema.Payment{})
+		Expect(err).NotTo(HaveOccurred())
+
+		sub, err := payments.Events(ctx, gateway.WithBlockRange(1, 0))
+		Expect(err).NotTo(HaveOccurred())
+
+		var e *gateway.TypedEvent
+		Eventually(sub.TypedEvents()).Should(Receive(&e))
+		Expect(e.Name).To(Equal(`PaymentEvent`))
+		Expect(e.TxId).NotTo(BeEmpty())
+		Expect(e.Message).To(BeAssignableToTypeOf(&paymentschema.PaymentEvent{}))
+		Expect(e.Message.(*paymentschema.PaymentEvent).Amount).To(Equal(int32(100)))
+	})
+})
@@ -0,0 +1,148 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/optherium/cckit/gateway/service"
+)
+
+// InvokeResult of chaincode invoke, submitted to orderer
+type InvokeResult struct {
+	// TxId transaction id of last attempt
+	TxId string
+	// Payload endorsement response payload, converted to target
+	Payload interface{}
+	// Attempts number of submitted transactions, more than 1 if invoke was retried
+	Attempts int
+
+	chaincode *chaincode
+	input     *service.ChaincodeInput
+	target    interface{}
+}
+
+// Wait waits for transaction commit, returns block number and validation code.
+// With retry policy (WithRetry) invoke is resubmitted if transaction is invalidated with retryable code,
+// Wait is not safe for concurrent use.
+// If transaction is invalid returns status with ErrTxInvalid
+func (r *InvokeResult) Wait(ctx context.Context) (*service.ChaincodeTxStatus, error) {
+	for {
+		status, err := r.chaincode.WaitTx(ctx, r.TxId)
+		if err == nil || status == nil || !r.chaincode.Retry.retryable(status.ValidationCode, r.Attempts) {
+			return status, err
+		}
+
+		if err = sleep(ctx, r.chaincode.Retry.backoff(r.Attempts)); err != nil {
+			return status, err
+		}
+
+		// same input with same transient map (i.e. idempotency key) is endorsed and submitted again
+		c, err := r.chaincode.context(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err = r.submit(c); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (r *InvokeResult) submit(ctx context.Context) error {
+	response, err := r.chaincode.Service.Submit(ctx, r.input)
+	if err != nil {
+		return err
+	}
+
+	payload, err := r.chaincode.ccOutput(ctx, Invoke, response.Response.Response, r.target)
+	if err != nil {
+		return err
+	}
+
+	r.TxId, r.Payload = response.TxId, payload
+	r.Attempts++
+	return nil
+}
+
+// InvokeWithResult invokes chaincode without waiting for transaction commit,
+// returns transaction id, endorsement payload and handle to wait for commit
+func (g *chaincode) InvokeWithResult(ctx context.Context, fn string, args []interface{}, target interface{}) (*InvokeResult, error) {
+	c, err := g.context(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ccInput, err := g.ccInput(c, Invoke, fn, args)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &InvokeResult{chaincode: g, input: ccInput, target: target}
+	if err = res.submit(c); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Submit sends invoke to orderer and returns transaction id, response payload is not decoded.
+// Transaction commit can be awaited later with WaitTx
+func (g *chaincode) Submit(ctx context.Context, fn string, args []interface{}) (string, error) {
+	c, err := g.context(ctx)
+	if err != nil {
+		return ``, err
+	}
+	ccInput, err := g.ccInput(c, Invoke, fn, args)
+	if err != nil {
+		return ``, err
+	}
+
+	response, err := g.Service.Submit(c, ccInput)
+	if err != nil {
+		return ``, err
+	}
+	return response.TxId, nil
+}
+
+// WaitTx waits for transaction commit, returns block number and validation code.
+// If transaction is invalid returns status with ErrTxInvalid
+func (g *chaincode) WaitTx(ctx context.Context, txId string) (*service.ChaincodeTxStatus, error) {
+	c, err := g.context(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := g.Service.TxStatus(c, &service.ChaincodeTxLocator{
+		Channel: g.Channel,
+		TxId:    txId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if status.ValidationCode != peer.TxValidationCode_VALID {
+		return status, fmt.Errorf(`%s: %s, tx=%s`, ErrTxInvalid, status.ValidationCode, txId)
+	}
+	return status, nil
+}
+
+// SubmitChecker is implemented by chaincode service, which can submit transactions only if configured,
+// i.e. service.ChaincodeService, created with service.WithSubmitter
+type SubmitChecker interface {
+	CanSubmit() bool
+}
+
+// invokeWithRetry submits invoke and waits for commit, resubmitting it according to retry policy
+func (g *chaincode) invokeWithRetry(ctx context.Context, fn string, args []interface{}, target interface{}) (interface{}, error) {
+	if checker, ok := g.Service.(SubmitChecker); ok && !checker.CanSubmit() {
+		return nil, ErrRetrySubmitterNotDefined
+	}
+
+	res, err := g.InvokeWithResult(ctx, fn, args, target)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = res.Wait(ctx); err != nil {
+		return nil, fmt.Errorf(`%s, attempts=%d`, err, res.Attempts)
+	}
+	return res.Payload, nil
+}
@@ -0,0 +1,58 @@
+package gateway_test
+
+import (
+	"context"
+	"crypto/x509/pkix"
+	"time"
+
+	"github.com/hyperledger/fabric/protos/peer"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/optherium/cckit/gateway"
+	"github.com/optherium/cckit/gateway/service"
+	testcc "github.com/optherium/cckit/testing"
+)
+
+var _ = Describe(`Invoke with result`, func() {
+
+	identity := testcc.MustGenerateIdentity(`MSP`, pkix.Name{CommonName: `invoker`}, nil)
+	ccService := service.NewMock().WithChannel(`tx`, testcc.NewMockStub(`emitter`, NewEmitter()))
+	emitter := gateway.NewChaincode(ccService, `tx`, `emitter`, gateway.WithDefaultSigner(identity))
+	ctx := context.Background()
+
+	It(`Allow to get tx id and payload, then wait for commit`, func() {
+		res, err := emitter.InvokeWithResult(ctx, `emit`, []interface{}{`Created`}, ``)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.TxId).NotTo(BeEmpty())
+		Expect(res.Payload).To(Equal(`Created`))
+
+		status, err := res.Wait(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.TxId).To(Equal(res.TxId))
+		Expect(status.Block).To(Equal(uint64(1)))
+		Expect(status.ValidationCode).To(Equal(peer.TxValidationCode_VALID))
+	})
+
+	It(`Allow to submit invoke and wait for commit later`, func() {
+		txId, err := emitter.Submit(ctx, `emit`, []interface{}{`Updated`})
+		Expect(err).NotTo(HaveOccurred())
+
+		status, err := emitter.WaitTx(ctx, txId)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Block).To(Equal(uint64(2)))
+	})
+
+	It(`Disallow to submit invoke with error`, func() {
+		_, err := emitter.Submit(ctx, `unknown`, nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It(`Allow to stop waiting for unknown tx with context`, func() {
+		waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		_, err := emitter.WaitTx(waitCtx, `unknown`)
+		Expect(err).To(Equal(context.DeadlineExceeded))
+	})
+})
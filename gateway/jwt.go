@@ -0,0 +1,79 @@
+package gateway
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+type (
+	jwtHeader struct {
+		Alg string `json:"alg"`
+	}
+
+	jwtClaims struct {
+		Sub string `json:"sub"`
+		Exp int64  `json:"exp"`
+		Nbf int64  `json:"nbf"`
+	}
+)
+
+// HS256Verifier verifies JWT, signed with HMAC SHA-256 shared secret, and its exp and nbf claims,
+// tokens without exp claim are rejected
+func HS256Verifier(secret []byte) JWTVerifier {
+	return func(token string) (string, error) {
+		parts := strings.Split(token, `.`)
+		if len(parts) != 3 {
+			return ``, errors.New(`malformed token`)
+		}
+
+		header := &jwtHeader{}
+		if err := jwtDecode(parts[0], header); err != nil {
+			return ``, err
+		}
+		if header.Alg != `HS256` {
+			return ``, errors.New(`unexpected signing algorithm ` + header.Alg)
+		}
+
+		signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+		if err != nil {
+			return ``, err
+		}
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(parts[0] + `.` + parts[1]))
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return ``, errors.New(`signature invalid`)
+		}
+
+		claims := &jwtClaims{}
+		if err = jwtDecode(parts[1], claims); err != nil {
+			return ``, err
+		}
+		now := time.Now().Unix()
+		if claims.Exp == 0 {
+			return ``, errors.New(`expiration time not defined`)
+		}
+		if now >= claims.Exp {
+			return ``, errors.New(`token expired`)
+		}
+		if claims.Nbf != 0 && now < claims.Nbf {
+			return ``, errors.New(`token not valid yet`)
+		}
+		if claims.Sub == `` {
+			return ``, errors.New(`subject not defined`)
+		}
+		return claims.Sub, nil
+	}
+}
+
+func jwtDecode(part string, target interface{}) error {
+	bb, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bb, target)
+}
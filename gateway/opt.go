@@ -2,9 +2,12 @@ package gateway
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 
 	"github.com/hyperledger/fabric/msp"
 	"github.com/optherium/cckit/extensions/encryption"
+	"github.com/optherium/cckit/state/mapping"
 
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/optherium/cckit/gateway/service"
@@ -17,6 +20,12 @@ type InputOpt func(action Action, input *service.ChaincodeInput) error
 type OutputOpt func(action Action, response *peer.Response) error
 type EventOpt func(event *peer.ChaincodeEvent) error
 
+// RequestIdFunc returns request id for invoke of chaincode method (fn) with args
+type RequestIdFunc func(ctx context.Context, fn string, args []interface{}) (string, error)
+
+// EventsOpt sets events stream request filters and position
+type EventsOpt func(req *service.ChaincodeEventsStreamRequest)
+
 func WithDefaultSigner(defaultSigner msp.SigningIdentity) Opt {
 	return func(c *chaincode) {
 		c.ContextOpts = append(c.ContextOpts, func(ctx context.Context) context.Context {
@@ -25,6 +34,42 @@ func WithDefaultSigner(defaultSigner msp.SigningIdentity) Opt {
 	}
 }
 
+// WithEventsBuffer sets size of events subscription buffer and policy, applied when buffer is full
+func WithEventsBuffer(size int, overflow OverflowPolicy) Opt {
+	return func(c *chaincode) {
+		c.EventsBuffer = EventsBuffer{Size: size, Overflow: overflow}
+	}
+}
+
+// WithRetry resubmits invokes, invalidated on commit with retryable validation code (i.e. MVCC_READ_CONFLICT).
+// Invoke waits for commit of every attempt, InvokeWithResult - resubmits on Wait.
+// Invokes are sent with Service.Submit, so service.ChaincodeService must be created with service.WithSubmitter,
+// otherwise Invoke returns ErrRetrySubmitterNotDefined
+func WithRetry(policy RetryPolicy) Opt {
+	return func(c *chaincode) {
+		if policy.MaxAttempts < 1 {
+			policy.MaxAttempts = 1
+		}
+		c.Retry = &policy
+	}
+}
+
+// WithQueryCache caches query results, cache can be shared by chaincodes.
+// Cached results are invalidated by events, passed to cache with QueryCache.Watch
+func WithQueryCache(cache *QueryCache) Opt {
+	return func(c *chaincode) {
+		c.Cache = cache
+	}
+}
+
+// WithSignerResolver resolves signing identity per request (i.e. from gRPC metadata), takes precedence over
+// default signer. Requests with no resolvable identity are rejected with Unauthenticated status
+func WithSignerResolver(resolver SignerResolver) Opt {
+	return func(c *chaincode) {
+		c.SignerResolver = resolver
+	}
+}
+
 func WithTransientValue(key string, value []byte) Opt {
 	return func(c *chaincode) {
 		c.ContextOpts = append(c.ContextOpts, func(ctx context.Context) context.Context {
@@ -77,3 +122,65 @@ func WithEventDecryption(encKey []byte) Opt {
 		})
 	}
 }
+
+// WithEventTypes decodes events payloads to registered types, decoded events are available via
+// ChaincodeEventSub.TypedEvents. Event opts (i.e. WithEventDecryption) are applied before decoding
+func WithEventTypes(types EventTypes) Opt {
+	return func(c *chaincode) {
+		c.EventTypes = types
+	}
+}
+
+// WithEventMappings decodes events payloads to types of chaincode event mappings
+func WithEventMappings(mappings mapping.EventMappings) Opt {
+	return WithEventTypes(EventTypesFromMappings(mappings))
+}
+
+// WithRequestId adds request id to transient map of every invoke, so chaincode with idempotency middleware
+// can deduplicate retried invokes. Request id, set by caller with ContextWithRequestId, takes precedence,
+// otherwise request id is returned by requestId func (i.e. derived from business key of invoke args).
+// Request id is set once per call, so resubmits of the same call (WithRetry) reuse it
+func WithRequestId(requestId RequestIdFunc) Opt {
+	return func(c *chaincode) {
+		c.RequestId = requestId
+	}
+}
+
+// RandomRequestId generates random request id on every call. Only resubmits within one call (WithRetry)
+// are deduplicated, call, retried by application, gets new request id - use ContextWithRequestId for that
+func RandomRequestId(context.Context, string, []interface{}) (string, error) {
+	requestId := make([]byte, 16)
+	if _, err := rand.Read(requestId); err != nil {
+		return ``, err
+	}
+	return hex.EncodeToString(requestId), nil
+}
+
+// WithEventNames streams events with one of names
+func WithEventNames(names ...string) EventsOpt {
+	return func(req *service.ChaincodeEventsStreamRequest) {
+		req.EventNames = append(req.EventNames, names...)
+	}
+}
+
+// WithEventNameRegexps streams events with name, matching one of regexps
+func WithEventNameRegexps(exprs ...string) EventsOpt {
+	return func(req *service.ChaincodeEventsStreamRequest) {
+		req.EventNameRegexps = append(req.EventNameRegexps, exprs...)
+	}
+}
+
+// WithBlockRange streams events from block to block inclusive, 0 - not limited
+func WithBlockRange(fromBlock, toBlock uint64) EventsOpt {
+	return func(req *service.ChaincodeEventsStreamRequest) {
+		req.FromBlock = fromBlock
+		req.ToBlock = toBlock
+	}
+}
+
+// WithCheckpoint resumes events stream after checkpoint, returned by ChaincodeEventSub.Checkpoint
+func WithCheckpoint(checkpoint *service.ChaincodeEventCheckpoint) EventsOpt {
+	return func(req *service.ChaincodeEventsStreamRequest) {
+		req.Checkpoint = checkpoint
+	}
+}
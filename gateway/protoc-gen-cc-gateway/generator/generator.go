@@ -79,6 +79,8 @@ func (g *Generator) getCCTemplate(f *descriptor.File) (string, error) {
 	pkgs := [][]string{
 		{"context", "context"},
 		{"github.com/pkg/errors", "errors"},
+		{"google.golang.org/grpc", "grpc"},
+		{"github.com/hyperledger/fabric/core/chaincode/shim", "shim"},
 		{"github.com/optherium/cckit/gateway", "cckit_gateway"},
 		{"github.com/optherium/cckit/gateway/service", "cckit_ccservice"},
 		{"github.com/optherium/cckit/router", "cckit_router"},
@@ -91,6 +93,22 @@ func (g *Generator) getCCTemplate(f *descriptor.File) (string, error) {
 		imports = append(imports, g.newGoPackage(pkg[0], pkg[1]))
 	}
 
+	// middleware packages, used in cckit method options
+	var usesRoles, usesEncryption bool
+	for _, svc := range f.Services {
+		for _, m := range svc.Methods {
+			opts := methodOptions(m)
+			usesRoles = usesRoles || len(opts.GetRoles()) > 0
+			usesEncryption = usesEncryption || opts.GetEncryptionRequired()
+		}
+	}
+	if usesRoles {
+		imports = append(imports, g.newGoPackage("github.com/optherium/cckit/extensions/rbac", "cckit_rbac"))
+	}
+	if usesEncryption {
+		imports = append(imports, g.newGoPackage("github.com/optherium/cckit/extensions/encryption", "cckit_encryption"))
+	}
+
 	for _, svc := range f.Services {
 		for _, m := range svc.Methods {
 			checkedAppend := func(pkg descriptor.GoPackage) {
@@ -161,6 +179,14 @@ func applyTemplate(p param) (string, error) {
 		return "", err
 	}
 
+	if err := clientTemplate.Execute(w, p); err != nil {
+		return "", err
+	}
+
+	if err := remoteTemplate.Execute(w, p); err != nil {
+		return "", err
+	}
+
 	return w.String(), nil
 }
 
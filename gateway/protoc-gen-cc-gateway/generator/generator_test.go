@@ -0,0 +1,106 @@
+package generator_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	protodescriptor "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"github.com/grpc-ecosystem/grpc-gateway/protoc-gen-grpc-gateway/descriptor"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/optherium/cckit/gateway/protoc-gen-cc-gateway/generator"
+	"github.com/optherium/cckit/gateway/protoc-gen-cc-gateway/options"
+)
+
+func TestGenerator(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Generator suite")
+}
+
+func method(name string, opts *options.MethodOptions) *protodescriptor.MethodDescriptorProto {
+	m := &protodescriptor.MethodDescriptorProto{
+		Name:       proto.String(name),
+		InputType:  proto.String(`.test.Request`),
+		OutputType: proto.String(`.test.Response`),
+	}
+	if opts != nil {
+		m.Options = &protodescriptor.MethodOptions{}
+		Expect(proto.SetExtension(m.Options, options.E_Method, opts)).To(Succeed())
+	}
+	return m
+}
+
+func generate(methods ...*protodescriptor.MethodDescriptorProto) string {
+	file := &protodescriptor.FileDescriptorProto{
+		Name:    proto.String(`test.proto`),
+		Package: proto.String(`test`),
+		Options: &protodescriptor.FileOptions{GoPackage: proto.String(`example.com/test`)},
+		MessageType: []*protodescriptor.DescriptorProto{
+			{Name: proto.String(`Request`)}, {Name: proto.String(`Response`)}},
+		Service: []*protodescriptor.ServiceDescriptorProto{{Name: proto.String(`Test`), Method: methods}},
+		Syntax:  proto.String(`proto3`),
+	}
+
+	reg := descriptor.NewRegistry()
+	Expect(reg.Load(&plugin.CodeGeneratorRequest{
+		FileToGenerate: []string{file.GetName()},
+		ProtoFile:      []*protodescriptor.FileDescriptorProto{file},
+	})).To(Succeed())
+
+	target, err := reg.LookupFile(file.GetName())
+	Expect(err).NotTo(HaveOccurred())
+
+	files, err := generator.New(reg).Generate([]*descriptor.File{target})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(files).To(HaveLen(1))
+	return files[0].GetContent()
+}
+
+var _ = Describe(`Method options`, func() {
+
+	It(`Allow to generate invoke by default`, func() {
+		code := generate(method(`Create`, nil))
+		Expect(code).To(ContainSubstring(`r.Invoke(TestChaincode_Create`))
+		Expect(code).To(ContainSubstring(`c.Gateway.Invoke(ctx, TestChaincode_Create`))
+		Expect(code).NotTo(ContainSubstring(`cckit_rbac`))
+		Expect(code).NotTo(ContainSubstring(`cckit_encryption`))
+		Expect(code).NotTo(ContainSubstring(`r.Pre(`))
+	})
+
+	It(`Allow to define method type`, func() {
+		code := generate(method(`Get`, &options.MethodOptions{Type: options.MethodType_QUERY}))
+		Expect(code).To(ContainSubstring(`r.Query(TestChaincode_Get`))
+		Expect(code).To(ContainSubstring(`c.Gateway.Query(ctx, TestChaincode_Get`))
+		Expect(code).To(ContainSubstring(`c.Client.Query(c.Stub, TestChaincode_Get`))
+	})
+
+	It(`Allow to define method middleware`, func() {
+		code := generate(method(`Create`, &options.MethodOptions{
+			Roles:              []string{`admin`, `issuer`},
+			EncryptionRequired: true,
+		}))
+		Expect(code).To(ContainSubstring(`cckit_rbac "github.com/optherium/cckit/extensions/rbac"`))
+		Expect(code).To(ContainSubstring(`cckit_encryption "github.com/optherium/cckit/extensions/encryption"`))
+		Expect(code).To(MatchRegexp(`r.Pre\(cckit_encryption.ArgsDecryptIfKeyProvided\)\s+r.Invoke\(TestChaincode_Create`))
+		Expect(code).To(MatchRegexp(`cckit_rbac.Only\("admin", "issuer"\),\s+` +
+			`cckit_encryption.EncryptInvokeResponse\(\),\s+cckit_encryption.EncStateContext,\s+cckit_defparam.Proto`))
+	})
+})
+
+var _ = Describe(`Remote gateway client`, func() {
+
+	It(`Allow to generate gateway client with gateway method set`, func() {
+		code := generate(method(`Create`, nil), method(`Get`, &options.MethodOptions{Type: options.MethodType_QUERY}))
+		Expect(code).To(ContainSubstring(`grpc "google.golang.org/grpc"`))
+		Expect(code).To(ContainSubstring(
+			`func NewTestGatewayClient(conn *grpc.ClientConn, channel, chaincode string, opts ...cckit_gateway.Opt) *TestGatewayClient`))
+		Expect(code).To(ContainSubstring(`if res, err := c.Client.Create(ctx, in); err != nil {`))
+		Expect(code).To(ContainSubstring(`return nil, cckit_ccservice.FromStatus(err)`))
+		Expect(code).To(ContainSubstring(`if res, err := c.Client.Get(ctx, in); err != nil {`))
+		Expect(code).To(ContainSubstring(`cckit_gateway.NewEventsConfig(opts...)`))
+		Expect(code).NotTo(ContainSubstring(`TypedEvents(`))
+		Expect(code).To(MatchRegexp(`type TestGatewayInterface interface {[^}]+Create\(ctx context.Context, in \*Request\) \(\*Response, error\)`))
+	})
+})
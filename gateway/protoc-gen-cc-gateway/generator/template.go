@@ -15,9 +15,12 @@ var (
 	pkg = make(map[string]string)
 
 	funcMap = template.FuncMap{
-		"goTypeName":    goTypeName,
-		"hasBindings":   hasBindings,
-		"hasGetBinding": hasGetBinding,
+		"goTypeName":           goTypeName,
+		"hasBindings":          hasBindings,
+		"hasGetBinding":        hasGetBinding,
+		"methodType":           methodType,
+		"methodMiddleware":     methodMiddleware,
+		"servicePreMiddleware": servicePreMiddleware,
 	}
 
 	headerTemplate = template.Must(template.New("header").Funcs(funcMap).Parse(`
@@ -28,6 +31,8 @@ var (
 Package {{ .GoPkg.Name }} contains
   *   chaincode interface definition
   *   chaincode gateway definition
+  *   chaincode client for calling from another chaincode
+  *   remote gateway client for calling gateway via gRPC
   *   chaincode service to cckit router registration func
 */
 package {{ .GoPkg.Name }}
@@ -64,12 +69,12 @@ type {{ $svc.GetName }}Chaincode interface {
 
 // Register{{ $svc.GetName }}Chaincode registers service methods as chaincode router handlers
 func Register{{ $svc.GetName }}Chaincode(r *cckit_router.Group, cc {{ $svc.GetName }}Chaincode) error {
+    {{ range $mw := $svc | servicePreMiddleware }}
+ r.Pre({{ $mw }})
+    {{ end }}
 
     {{ range $m := $svc.Methods }}
-    {{ $method := "Invoke"}}
-    {{ if $m | hasGetBinding }}{{ $method = "Query"}}{{ end }}
-
- r.{{ $method }}( {{ $svc.GetName }}Chaincode_{{ $m.GetName }}, 
+ r.{{ $m | methodType }}( {{ $svc.GetName }}Chaincode_{{ $m.GetName }}, 
 		func(ctx cckit_router.Context) (interface{}, error) {
             if v, ok := ctx.Param().(ValidatorInterface); ok {
               if err := v.Validate(); err != nil {
@@ -78,7 +83,8 @@ func Register{{ $svc.GetName }}Chaincode(r *cckit_router.Group, cc {{ $svc.GetNa
             }
 			return cc.{{ $m.GetName }}(ctx, ctx.Param().(*{{$m.RequestType.GoType $m.Service.File.GoPkg.Path | goTypeName }}))
 		},
-		cckit_defparam.Proto(&{{$m.RequestType.GoType $m.Service.File.GoPkg.Path | goTypeName }}{}))
+		{{ range $mw := $m | methodMiddleware }}{{ $mw }},
+		{{ end }}cckit_defparam.Proto(&{{$m.RequestType.GoType $m.Service.File.GoPkg.Path | goTypeName }}{}))
 
    {{ end }}
 
@@ -91,6 +97,15 @@ func Register{{ $svc.GetName }}Chaincode(r *cckit_router.Group, cc {{ $svc.GetNa
 var gatewayTemplate = template.Must(template.New("gateway").Funcs(funcMap).Option().Parse(`
 {{ range $svc := .Services }}
 
+// {{ $svc.GetName }}GatewayInterface is implemented by local chaincode gateway and remote gateway client
+type {{ $svc.GetName }}GatewayInterface interface {
+	ApiDef() cckit_gateway.ServiceDef
+	Events(ctx context.Context, opts ...cckit_gateway.EventsOpt) (cckit_gateway.ChaincodeEventSub, error)
+{{ range $m := $svc.Methods }}
+	{{ $m.GetName }}(ctx context.Context, in *{{$m.RequestType.GoType $m.Service.File.GoPkg.Path | goTypeName }}) (*{{ $m.ResponseType.GoType $m.Service.File.GoPkg.Path | goTypeName }}, error)
+{{ end }}
+}
+
 // New{{ $svc.GetName }}Gateway creates gateway to access chaincode method via chaincode service
 func New{{ $svc.GetName }}Gateway(ccService cckit_ccservice.Chaincode, channel, chaincode string, opts ...cckit_gateway.Opt) *{{ $svc.GetName }}Gateway {
 	return &{{ $svc.GetName }}Gateway{Gateway: cckit_gateway.NewChaincode(ccService, channel, chaincode, opts...)}
@@ -112,14 +127,13 @@ func (c *{{ $svc.GetName }}Gateway) ApiDef() cckit_gateway.ServiceDef {
 	}
 }
 
-// Events returns events subscription
-func (c *{{ $svc.GetName }}Gateway) Events(ctx context.Context) (cckit_gateway.ChaincodeEventSub, error) {
-   return c.Gateway.Events(ctx)
+// Events returns events subscription, opts define events stream filters and position.
+// Subscription TypedEvents decodes payloads to types, registered with WithEventTypes or WithEventMappings
+func (c *{{ $svc.GetName }}Gateway) Events(ctx context.Context, opts ...cckit_gateway.EventsOpt) (cckit_gateway.ChaincodeEventSub, error) {
+   return c.Gateway.Events(ctx, opts...)
 }
 
  {{ range $m := $svc.Methods }}
- {{ $method := "Invoke"}}
- {{ if $m | hasGetBinding }}{{ $method = "Query"}}{{ end }}
 
  func (c *{{ $svc.GetName }}Gateway) {{ $m.GetName }}(ctx context.Context, in *{{$m.RequestType.GoType $m.Service.File.GoPkg.Path | goTypeName }}) (*{{ $m.ResponseType.GoType $m.Service.File.GoPkg.Path | goTypeName }}, error) {
     var inMsg interface{} = in
@@ -129,7 +143,42 @@ func (c *{{ $svc.GetName }}Gateway) Events(ctx context.Context) (cckit_gateway.C
 	   } 
      }
 
-    if res, err := c.Gateway.{{ $method }}(ctx, {{ $svc.GetName }}Chaincode_{{ $m.GetName }} , []interface{}{in}, &{{ $m.ResponseType.GoType $m.Service.File.GoPkg.Path | goTypeName }}{}); err != nil {
+    if res, err := c.Gateway.{{ $m | methodType }}(ctx, {{ $svc.GetName }}Chaincode_{{ $m.GetName }} , []interface{}{in}, &{{ $m.ResponseType.GoType $m.Service.File.GoPkg.Path | goTypeName }}{}); err != nil {
+		return nil, err
+	} else {
+		return res.(*{{ $m.ResponseType.GoType $m.Service.File.GoPkg.Path | goTypeName }}), nil
+	}
+ }
+ {{ end }}
+
+{{ end }}
+`))
+
+var clientTemplate = template.Must(template.New("client").Funcs(funcMap).Option().Parse(`
+{{ range $svc := .Services }}
+
+// New{{ $svc.GetName }}ChaincodeClient creates client for calling chaincode methods from another chaincode
+func New{{ $svc.GetName }}ChaincodeClient(stub shim.ChaincodeStubInterface, channel, chaincode string, opts ...cckit_gateway.Opt) *{{ $svc.GetName }}ChaincodeClient {
+	return &{{ $svc.GetName }}ChaincodeClient{Stub: stub, Client: cckit_gateway.NewChaincodeClient(channel, chaincode, opts...)}
+}
+
+// {{ $svc.GetName }}ChaincodeClient calls chaincode methods via stub.InvokeChaincode
+type {{ $svc.GetName }}ChaincodeClient struct {
+	Stub   shim.ChaincodeStubInterface
+	Client cckit_gateway.ChaincodeInvokeClient
+}
+
+ {{ range $m := $svc.Methods }}
+
+ func (c *{{ $svc.GetName }}ChaincodeClient) {{ $m.GetName }}(in *{{$m.RequestType.GoType $m.Service.File.GoPkg.Path | goTypeName }}) (*{{ $m.ResponseType.GoType $m.Service.File.GoPkg.Path | goTypeName }}, error) {
+    var inMsg interface{} = in
+    if v, ok := inMsg.(ValidatorInterface); ok {
+       if err := v.Validate(); err != nil {
+		return nil, err
+	   }
+     }
+
+    if res, err := c.Client.{{ $m | methodType }}(c.Stub, {{ $svc.GetName }}Chaincode_{{ $m.GetName }} , []interface{}{in}, &{{ $m.ResponseType.GoType $m.Service.File.GoPkg.Path | goTypeName }}{}); err != nil {
 		return nil, err
 	} else {
 		return res.(*{{ $m.ResponseType.GoType $m.Service.File.GoPkg.Path | goTypeName }}), nil
@@ -139,3 +188,59 @@ func (c *{{ $svc.GetName }}Gateway) Events(ctx context.Context) (cckit_gateway.C
 
 {{ end }}
 `))
+
+var remoteTemplate = template.Must(template.New("remote").Funcs(funcMap).Option().Parse(`
+{{ range $svc := .Services }}
+
+// New{{ $svc.GetName }}GatewayClient creates client to remote chaincode gateway, exposed via gRPC (i.e. by gateway server),
+// opts define events processing (i.e. WithEventDecryption, WithEventTypes)
+func New{{ $svc.GetName }}GatewayClient(conn *grpc.ClientConn, channel, chaincode string, opts ...cckit_gateway.Opt) *{{ $svc.GetName }}GatewayClient {
+	return &{{ $svc.GetName }}GatewayClient{
+		Client:       New{{ $svc.GetName }}Client(conn),
+		RemoteEvents: cckit_gateway.NewRemoteEvents(
+			cckit_ccservice.NewChaincodeClient(conn), channel, chaincode, cckit_gateway.NewEventsConfig(opts...)),
+	}
+}
+
+// {{ $svc.GetName }}GatewayClient calls chaincode methods of remote gateway via gRPC, has the same method set as gateway
+type {{ $svc.GetName }}GatewayClient struct {
+	Client       {{ $svc.GetName }}Client
+	RemoteEvents *cckit_gateway.RemoteEvents
+}
+
+// ApiDef returns service definition, gateway client can be exposed as proxy to remote gateway
+func (c *{{ $svc.GetName }}GatewayClient) ApiDef() cckit_gateway.ServiceDef {
+	return cckit_gateway.ServiceDef{
+		Desc:                        &_{{ $svc.GetName }}_serviceDesc,
+		Service:                     c,
+		HandlerFromEndpointRegister: Register{{ $svc.GetName }}HandlerFromEndpoint,
+	}
+}
+
+// Events returns events subscription, opts define events stream filters and position.
+// Subscription TypedEvents decodes payloads to types, registered with WithEventTypes or WithEventMappings
+func (c *{{ $svc.GetName }}GatewayClient) Events(ctx context.Context, opts ...cckit_gateway.EventsOpt) (cckit_gateway.ChaincodeEventSub, error) {
+   return c.RemoteEvents.Events(ctx, opts...)
+}
+
+ {{ range $m := $svc.Methods }}
+
+ func (c *{{ $svc.GetName }}GatewayClient) {{ $m.GetName }}(ctx context.Context, in *{{$m.RequestType.GoType $m.Service.File.GoPkg.Path | goTypeName }}) (*{{ $m.ResponseType.GoType $m.Service.File.GoPkg.Path | goTypeName }}, error) {
+    var inMsg interface{} = in
+    if v, ok := inMsg.(ValidatorInterface); ok {
+       if err := v.Validate(); err != nil {
+		return nil, err
+	   }
+     }
+
+    if res, err := c.Client.{{ $m.GetName }}(ctx, in); err != nil {
+       // decode chaincode error from gRPC status details
+       return nil, cckit_ccservice.FromStatus(err)
+    } else {
+       return res, nil
+    }
+ }
+ {{ end }}
+
+{{ end }}
+`))
@@ -1,10 +1,14 @@
 package generator
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/protoc-gen-go/generator"
 	"github.com/grpc-ecosystem/grpc-gateway/protoc-gen-grpc-gateway/descriptor"
+	"github.com/optherium/cckit/gateway/protoc-gen-cc-gateway/options"
 )
 
 func hasBindings(service *descriptor.Service) bool {
@@ -25,6 +29,65 @@ func hasGetBinding(method *descriptor.Method) bool {
 	return false
 }
 
+// methodOptions returns cckit method options, nil if not defined
+func methodOptions(method *descriptor.Method) *options.MethodOptions {
+	if method.Options == nil || !proto.HasExtension(method.Options, options.E_Method) {
+		return nil
+	}
+	ext, err := proto.GetExtension(method.Options, options.E_Method)
+	if err != nil {
+		return nil
+	}
+	return ext.(*options.MethodOptions)
+}
+
+// methodType returns Query or Invoke, cckit method option takes precedence over http GET binding
+func methodType(method *descriptor.Method) string {
+	switch methodOptions(method).GetType() {
+	case options.MethodType_QUERY:
+		return "Query"
+	case options.MethodType_INVOKE:
+		return "Invoke"
+	}
+
+	if hasGetBinding(method) {
+		return "Query"
+	}
+	return "Invoke"
+}
+
+// methodMiddleware returns router middleware, defined by cckit method options
+func methodMiddleware(method *descriptor.Method) []string {
+	var middleware []string
+	opts := methodOptions(method)
+
+	if roles := opts.GetRoles(); len(roles) > 0 {
+		quoted := make([]string, len(roles))
+		for i, role := range roles {
+			quoted[i] = strconv.Quote(role)
+		}
+		middleware = append(middleware, fmt.Sprintf("%s.Only(%s)", pkg["cckit_rbac"], strings.Join(quoted, ", ")))
+	}
+
+	if opts.GetEncryptionRequired() {
+		middleware = append(middleware,
+			pkg["cckit_encryption"]+".EncryptInvokeResponse()", pkg["cckit_encryption"]+".EncStateContext")
+	}
+	return middleware
+}
+
+// servicePreMiddleware returns router pre middleware, required by cckit options of service methods
+func servicePreMiddleware(service *descriptor.Service) []string {
+	for _, method := range service.Methods {
+		// args are decrypted before routing as method name is encrypted too,
+		// key presence is checked by EncStateContext method middleware
+		if methodOptions(method).GetEncryptionRequired() {
+			return []string{pkg["cckit_encryption"] + ".ArgsDecryptIfKeyProvided"}
+		}
+	}
+	return nil
+}
+
 func goTypeName(s string) string {
 	toks := strings.Split(s, ".")
 	i := 0
@@ -0,0 +1,79 @@
+package gateway
+
+import (
+	"context"
+	"io"
+
+	"github.com/optherium/cckit/gateway/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RemoteEvents subscribes to chaincode events, streamed by remote chaincode service via gRPC (i.e. gateway server)
+type RemoteEvents struct {
+	Client    service.ChaincodeClient
+	Channel   string
+	Chaincode string
+	EventsConfig
+}
+
+// NewRemoteEvents creates events subscriber, config defines event opts (i.e. WithEventDecryption),
+// event types and events buffer
+func NewRemoteEvents(client service.ChaincodeClient, channel, chaincode string, config EventsConfig) *RemoteEvents {
+	return &RemoteEvents{
+		Client:       client,
+		Channel:      channel,
+		Chaincode:    chaincode,
+		EventsConfig: config,
+	}
+}
+
+// Events returns events subscription, opts define events stream filters and position
+func (r *RemoteEvents) Events(ctx context.Context, opts ...EventsOpt) (ChaincodeEventSub, error) {
+	req, err := eventsStreamRequest(r.Channel, r.Chaincode, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	events, err := r.Client.EventsStream(ctx, req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	stream := r.EventsConfig.Stream(ctx)
+
+	// gRPC stream is cancelled when subscription is closed by consumer
+	go func() {
+		select {
+		case <-stream.closed:
+		case <-stream.finished:
+		}
+		cancel()
+	}()
+
+	go func() {
+		for {
+			e, err := events.Recv()
+			if err != nil {
+				stream.Finish(remoteStreamErr(ctx, err))
+				return
+			}
+			if err = stream.SendMsg(e); err != nil {
+				stream.Finish(err)
+				return
+			}
+		}
+	}()
+
+	return stream, nil
+}
+
+// remoteStreamErr returns nil if stream is ended by server or cancelled by consumer
+func remoteStreamErr(ctx context.Context, err error) error {
+	if err == io.EOF || ctx.Err() != nil || status.Code(err) == codes.Canceled {
+		return nil
+	}
+	return err
+}
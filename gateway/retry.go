@@ -0,0 +1,75 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/fabric/protos/peer"
+)
+
+// DefaultRetryableCodes validation codes of transactions, invalidated by concurrent transactions
+var DefaultRetryableCodes = []peer.TxValidationCode{
+	peer.TxValidationCode_MVCC_READ_CONFLICT,
+	peer.TxValidationCode_PHANTOM_READ_CONFLICT,
+}
+
+// RetryPolicy defines resubmitting of invokes, invalidated on commit
+type RetryPolicy struct {
+	// MaxAttempts number of submitted transactions, including first
+	MaxAttempts int
+	// Backoff delay before second attempt, doubled for every next attempt
+	Backoff time.Duration
+	// MaxBackoff limits delay between attempts, 0 - not limited
+	MaxBackoff time.Duration
+	// RetryableCodes validation codes of transactions to resubmit, DefaultRetryableCodes if not set
+	RetryableCodes []peer.TxValidationCode
+}
+
+// retryable returns true if transaction, invalidated with code after number of attempts, can be resubmitted
+func (p *RetryPolicy) retryable(code peer.TxValidationCode, attempts int) bool {
+	if p == nil || attempts >= p.MaxAttempts {
+		return false
+	}
+
+	codes := p.RetryableCodes
+	if len(codes) == 0 {
+		codes = DefaultRetryableCodes
+	}
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns delay after number of attempts
+func (p *RetryPolicy) backoff(attempts int) time.Duration {
+	delay := p.Backoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return delay
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,160 @@
+package gateway_test
+
+import (
+	"context"
+	"crypto/x509/pkix"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/protos/peer"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/optherium/cckit/extensions/idempotency"
+	"github.com/optherium/cckit/gateway"
+	"github.com/optherium/cckit/gateway/service"
+	testcc "github.com/optherium/cckit/testing"
+)
+
+// ConflictingService invalidates first commits with validation code
+type ConflictingService struct {
+	*service.MockChaincodeService
+	Code      peer.TxValidationCode
+	Conflicts int
+
+	m          sync.Mutex
+	submitted  []*service.ChaincodeInput
+	statusReqs int
+}
+
+func (s *ConflictingService) Submit(ctx context.Context, in *service.ChaincodeInput) (*service.ChaincodeSubmitResponse, error) {
+	s.m.Lock()
+	s.submitted = append(s.submitted, in)
+	s.m.Unlock()
+	return s.MockChaincodeService.Submit(ctx, in)
+}
+
+func (s *ConflictingService) TxStatus(ctx context.Context, in *service.ChaincodeTxLocator) (*service.ChaincodeTxStatus, error) {
+	status, err := s.MockChaincodeService.TxStatus(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.statusReqs++; s.statusReqs <= s.Conflicts {
+		status.ValidationCode = s.Code
+	}
+	return status, nil
+}
+
+var _ = Describe(`Invoke retry`, func() {
+
+	identity := testcc.MustGenerateIdentity(`MSP`, pkix.Name{CommonName: `invoker`}, nil)
+	ctx := context.Background()
+
+	conflicting := func(code peer.TxValidationCode, conflicts int) *ConflictingService {
+		return &ConflictingService{
+			MockChaincodeService: service.NewMock().WithChannel(`retry`, testcc.NewMockStub(`emitter`, NewEmitter())),
+			Code:                 code,
+			Conflicts:            conflicts,
+		}
+	}
+
+	policy := gateway.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}
+
+	It(`Allow to resubmit invoke on MVCC read conflict`, func() {
+		ccService := conflicting(peer.TxValidationCode_MVCC_READ_CONFLICT, 2)
+		emitter := gateway.NewChaincode(ccService, `retry`, `emitter`,
+			gateway.WithDefaultSigner(identity), gateway.WithRetry(policy), gateway.WithRequestId(gateway.RandomRequestId))
+
+		res, err := emitter.InvokeWithResult(ctx, `emit`, []interface{}{`Created`}, ``)
+		Expect(err).NotTo(HaveOccurred())
+		firstTxId := res.TxId
+
+		status, err := res.Wait(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.ValidationCode).To(Equal(peer.TxValidationCode_VALID))
+		Expect(res.Attempts).To(Equal(3))
+		Expect(res.TxId).NotTo(Equal(firstTxId))
+		Expect(res.Payload).To(Equal(`Created`))
+
+		// idempotency key is reused
+		Expect(ccService.submitted).To(HaveLen(3))
+		requestId := ccService.submitted[0].Transient[idempotency.TransientMapKey]
+		Expect(requestId).NotTo(BeEmpty())
+		for _, in := range ccService.submitted {
+			Expect(in.Transient[idempotency.TransientMapKey]).To(Equal(requestId))
+		}
+	})
+
+	It(`Allow to set request id by caller or request id func`, func() {
+		ccService := conflicting(peer.TxValidationCode_MVCC_READ_CONFLICT, 0)
+		emitter := gateway.NewChaincode(ccService, `retry`, `emitter`, gateway.WithDefaultSigner(identity),
+			gateway.WithRequestId(func(_ context.Context, fn string, args []interface{}) (string, error) {
+				return fn + `-` + args[0].(string), nil
+			}))
+
+		_, err := emitter.Submit(ctx, `emit`, []interface{}{`Created`})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = emitter.Submit(gateway.ContextWithRequestId(ctx, `req-1`), `emit`, []interface{}{`Created`})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(ccService.submitted).To(HaveLen(2))
+		Expect(string(ccService.submitted[0].Transient[idempotency.TransientMapKey])).To(Equal(`emit-Created`))
+		Expect(string(ccService.submitted[1].Transient[idempotency.TransientMapKey])).To(Equal(`req-1`))
+	})
+
+	It(`Allow to retry invoke`, func() {
+		emitter := gateway.NewChaincode(conflicting(peer.TxValidationCode_PHANTOM_READ_CONFLICT, 1), `retry`, `emitter`,
+			gateway.WithDefaultSigner(identity), gateway.WithRetry(policy))
+
+		res, err := emitter.Invoke(ctx, `emit`, []interface{}{`Created`}, ``)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res).To(Equal(`Created`))
+	})
+
+	It(`Disallow to retry with service, which can't submit transactions`, func() {
+		emitter := gateway.NewChaincode(service.New(nil), `retry`, `emitter`,
+			gateway.WithDefaultSigner(identity), gateway.WithRetry(policy))
+
+		_, err := emitter.Invoke(ctx, `emit`, []interface{}{`Created`}, ``)
+		Expect(err).To(Equal(gateway.ErrRetrySubmitterNotDefined))
+	})
+
+	It(`Disallow to retry more than max attempts`, func() {
+		ccService := conflicting(peer.TxValidationCode_MVCC_READ_CONFLICT, 5)
+		emitter := gateway.NewChaincode(ccService, `retry`, `emitter`,
+			gateway.WithDefaultSigner(identity), gateway.WithRetry(policy))
+
+		_, err := emitter.Invoke(ctx, `emit`, []interface{}{`Created`}, ``)
+		Expect(err).To(MatchError(ContainSubstring(gateway.ErrTxInvalid.Error())))
+		Expect(err).To(MatchError(ContainSubstring(`attempts=3`)))
+		Expect(ccService.submitted).To(HaveLen(3))
+	})
+
+	It(`Disallow to retry not retryable validation code`, func() {
+		ccService := conflicting(peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE, 1)
+		emitter := gateway.NewChaincode(ccService, `retry`, `emitter`,
+			gateway.WithDefaultSigner(identity), gateway.WithRetry(policy))
+
+		res, err := emitter.InvokeWithResult(ctx, `emit`, []interface{}{`Created`}, ``)
+		Expect(err).NotTo(HaveOccurred())
+
+		status, err := res.Wait(ctx)
+		Expect(err).To(MatchError(ContainSubstring(gateway.ErrTxInvalid.Error())))
+		Expect(status.ValidationCode).To(Equal(peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE))
+		Expect(res.Attempts).To(Equal(1))
+	})
+
+	It(`Allow to retry with custom validation codes`, func() {
+		emitter := gateway.NewChaincode(conflicting(peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE, 1), `retry`, `emitter`,
+			gateway.WithDefaultSigner(identity), gateway.WithRetry(gateway.RetryPolicy{
+				MaxAttempts:    2,
+				RetryableCodes: []peer.TxValidationCode{peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE},
+			}))
+
+		_, err := emitter.Invoke(ctx, `emit`, []interface{}{`Created`}, ``)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
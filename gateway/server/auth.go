@@ -0,0 +1,51 @@
+package server
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/optherium/cckit/gateway"
+)
+
+// signerResolver creates resolver, looking up caller identity by API key, JWT subject or client certificate
+func signerResolver(conf *AuthConfig, b backend) (gateway.SignerResolver, error) {
+	store := make(gateway.IdentityMap)
+	for _, caller := range conf.Callers {
+		signer, err := b.Signer(caller.Identity)
+		if err != nil {
+			return nil, err
+		}
+		store[caller.Id] = signer
+	}
+
+	var callerIds []gateway.CallerIdFunc
+	if conf.APIKeyHeader != `` {
+		callerIds = append(callerIds, gateway.APIKeyCallerId(strings.ToLower(conf.APIKeyHeader)))
+	}
+	if conf.JWTSecretFile != `` {
+		secret, err := ioutil.ReadFile(conf.JWTSecretFile)
+		if err != nil {
+			return nil, err
+		}
+		callerIds = append(callerIds, gateway.JWTSubjectCallerId(gateway.HS256Verifier(bytes.TrimSpace(secret))))
+	}
+	// credentials from headers take precedence, REST proxy connection has server certificate
+	if conf.ClientCert {
+		callerIds = append(callerIds, gateway.TLSCertCallerId())
+	}
+
+	return gateway.NewSignerResolver(store, callerIds...), nil
+}
+
+// headerMatcher forwards authorization and API key HTTP headers to gRPC metadata with the same keys
+func (s *Server) headerMatcher(key string) (string, bool) {
+	if s.config.Auth != nil {
+		if strings.EqualFold(key, gateway.AuthorizationHeader) ||
+			(s.config.Auth.APIKeyHeader != `` && strings.EqualFold(key, s.config.Auth.APIKeyHeader)) {
+			return strings.ToLower(key), true
+		}
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
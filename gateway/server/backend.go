@@ -0,0 +1,152 @@
+package server
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/optherium/cckit/gateway/service"
+	"github.com/optherium/cckit/testing"
+	"github.com/s7techlab/hlf-sdk-go/api/config"
+	"github.com/s7techlab/hlf-sdk-go/client"
+	"github.com/s7techlab/hlf-sdk-go/crypto"
+	_ "github.com/s7techlab/hlf-sdk-go/crypto/ecdsa"
+	"github.com/s7techlab/hlf-sdk-go/discovery"
+	_ "github.com/s7techlab/hlf-sdk-go/discovery/local"
+	hlfidentity "github.com/s7techlab/hlf-sdk-go/identity"
+	"github.com/s7techlab/hlf-sdk-go/logger"
+	"github.com/s7techlab/hlf-sdk-go/orderer"
+)
+
+// backend creates chaincode service and signing identities
+type backend interface {
+	Service() service.Chaincode
+	Signer(name string) (msp.SigningIdentity, error)
+}
+
+type (
+	mockBackend struct {
+		config  *Config
+		service *service.MockChaincodeService
+	}
+
+	sdkBackend struct {
+		config    *Config
+		sdkConfig *config.Config
+		service   *service.ChaincodeService
+	}
+)
+
+func newBackend(conf *Config, chaincodes map[string]ChaincodeFactory) (backend, error) {
+	switch conf.Backend.Type {
+	case BackendMock:
+		return newMockBackend(conf, chaincodes)
+	case BackendSDK:
+		return newSDKBackend(conf)
+	default:
+		return nil, fmt.Errorf(`%s: %s`, ErrUnknownBackend, conf.Backend.Type)
+	}
+}
+
+// newMockBackend creates mock stubs for configured chaincodes and inits them from chaincode identity
+func newMockBackend(conf *Config, chaincodes map[string]ChaincodeFactory) (*mockBackend, error) {
+	b := &mockBackend{config: conf, service: service.NewMock()}
+
+	for _, ccConf := range conf.Chaincodes {
+		factory, ok := chaincodes[ccConf.Name]
+		if !ok {
+			return nil, fmt.Errorf(`%s: %s`, ErrChaincodeNotRegistered, ccConf.Name)
+		}
+		cc, err := factory()
+		if err != nil {
+			return nil, err
+		}
+
+		signer, err := b.Signer(ccConf.Identity)
+		if err != nil {
+			return nil, err
+		}
+
+		stub := testing.NewMockStub(ccConf.Name, cc)
+		if res := stub.From(signer).Init(); res.Status >= shim.ERRORTHRESHOLD {
+			return nil, fmt.Errorf(`%s: %s: %s`, ErrChaincodeInitFailed, ccConf.Name, res.Message)
+		}
+		b.service.WithChannel(ccConf.Channel, stub)
+	}
+	return b, nil
+}
+
+func (b *mockBackend) Service() service.Chaincode {
+	return b.service
+}
+
+// Signer returns identity from certificate, mock stub doesn't check signatures
+func (b *mockBackend) Signer(name string) (msp.SigningIdentity, error) {
+	idConf, err := b.config.Identity(name)
+	if err != nil {
+		return nil, err
+	}
+	return testing.IdentityFromFile(idConf.MspId, idConf.CertFile, ioutil.ReadFile)
+}
+
+func newSDKBackend(conf *Config) (*sdkBackend, error) {
+	sdkConfig, err := config.NewYamlConfig(conf.Backend.SDKConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	idConf, err := conf.Identity(conf.Backend.Identity)
+	if err != nil {
+		return nil, err
+	}
+	id, err := hlfidentity.NewMSPIdentity(idConf.MspId, idConf.CertFile, idConf.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// orderer and discovery provider are shared with service for submitting transactions without commit waiting
+	ord, err := orderer.New(sdkConfig.Orderer, logger.DefaultLogger)
+	if err != nil {
+		return nil, err
+	}
+
+	core, err := client.NewCore(idConf.MspId, id, client.WithConfigRaw(*sdkConfig), client.WithOrderer(ord))
+	if err != nil {
+		return nil, err
+	}
+
+	dp, err := discovery.GetProvider(sdkConfig.Discovery.Type)
+	if err != nil {
+		return nil, err
+	}
+	if dp, err = dp.Initialize(sdkConfig.Discovery.Options, core.PeerPool()); err != nil {
+		return nil, err
+	}
+
+	return &sdkBackend{
+		config:    conf,
+		sdkConfig: sdkConfig,
+		service:   service.New(core, service.WithSubmitter(ord, dp)),
+	}, nil
+}
+
+func (b *sdkBackend) Service() service.Chaincode {
+	return b.service
+}
+
+func (b *sdkBackend) Signer(name string) (msp.SigningIdentity, error) {
+	idConf, err := b.config.Identity(name)
+	if err != nil {
+		return nil, err
+	}
+	id, err := hlfidentity.NewMSPIdentity(idConf.MspId, idConf.CertFile, idConf.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	cs, err := crypto.GetSuite(b.sdkConfig.Crypto.Type, b.sdkConfig.Crypto.Options)
+	if err != nil {
+		return nil, err
+	}
+	return id.GetSigningIdentity(cs), nil
+}
@@ -0,0 +1,195 @@
+package server
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// BackendMock - chaincodes are run in-process with MockStub, for local development
+	BackendMock = `mock`
+	// BackendSDK - chaincodes are invoked on Hyperledger Fabric network with hlf-sdk-go
+	BackendSDK = `sdk`
+
+	DefaultShutdownTimeout = 10 * time.Second
+)
+
+type (
+	// Config gateway server config
+	Config struct {
+		GRPC            ListenConfig      `yaml:"grpc"`
+		REST            ListenConfig      `yaml:"rest"`
+		Backend         BackendConfig     `yaml:"backend"`
+		Identities      []IdentityConfig  `yaml:"identities"`
+		Chaincodes      []ChaincodeConfig `yaml:"chaincodes"`
+		Auth            *AuthConfig       `yaml:"auth"`
+		ShutdownTimeout time.Duration     `yaml:"shutdown_timeout"`
+	}
+
+	// ListenConfig server listen address, TLS is not used if not defined
+	ListenConfig struct {
+		Address string     `yaml:"address"`
+		TLS     *TLSConfig `yaml:"tls"`
+	}
+
+	// TLSConfig server certificate and key, client certificates are verified if client CA defined
+	TLSConfig struct {
+		CertFile     string `yaml:"cert_file"`
+		KeyFile      string `yaml:"key_file"`
+		ClientCAFile string `yaml:"client_ca_file"`
+	}
+
+	// BackendConfig chaincode service backend, identity is used by sdk backend for peer connections
+	BackendConfig struct {
+		Type      string `yaml:"type"`
+		SDKConfig string `yaml:"sdk_config"`
+		Identity  string `yaml:"identity"`
+	}
+
+	// IdentityConfig signing identity, key is not required for mock backend
+	IdentityConfig struct {
+		Name     string `yaml:"name"`
+		MspId    string `yaml:"msp_id"`
+		CertFile string `yaml:"cert_file"`
+		KeyFile  string `yaml:"key_file"`
+	}
+
+	// ChaincodeConfig chaincode, exposed via registered service, requests are signed with identity
+	// if auth is not defined
+	ChaincodeConfig struct {
+		Name     string `yaml:"name"`
+		Channel  string `yaml:"channel"`
+		Service  string `yaml:"service"`
+		Identity string `yaml:"identity"`
+	}
+
+	// AuthConfig resolves signing identity per request by caller credentials, requests without
+	// known credentials are rejected. Caller id is taken from API key, JWT subject or client certificate common name,
+	// REST requests are proxied to gRPC with server certificate, so its common name must not be used as caller id
+	AuthConfig struct {
+		// APIKeyHeader gRPC metadata key or HTTP header with API key
+		APIKeyHeader string `yaml:"api_key_header"`
+		// JWTSecretFile file with HS256 secret for verifying bearer tokens
+		JWTSecretFile string `yaml:"jwt_secret_file"`
+		// ClientCert uses common name of verified client certificate of gRPC connection
+		ClientCert bool           `yaml:"client_cert"`
+		Callers    []CallerConfig `yaml:"callers"`
+	}
+
+	// CallerConfig maps caller id to identity
+	CallerConfig struct {
+		Id       string `yaml:"id"`
+		Identity string `yaml:"identity"`
+	}
+)
+
+// LoadConfig reads YAML config from file, relative file paths in config are resolved against config file dir
+func LoadConfig(path string) (*Config, error) {
+	bb, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config, err := ParseConfig(bb)
+	if err != nil {
+		return nil, err
+	}
+	config.ResolvePaths(filepath.Dir(path))
+	return config, nil
+}
+
+// ParseConfig parses YAML config and checks it
+func ParseConfig(bb []byte) (*Config, error) {
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(bb, config); err != nil {
+		return nil, err
+	}
+	if config.ShutdownTimeout == 0 {
+		config.ShutdownTimeout = DefaultShutdownTimeout
+	}
+	return config, config.Validate()
+}
+
+// Validate checks config consistency
+func (c *Config) Validate() error {
+	if c.GRPC.Address == `` {
+		return fmt.Errorf(`%s: grpc address`, ErrConfigInvalid)
+	}
+
+	switch c.Backend.Type {
+	case BackendMock:
+	case BackendSDK:
+		if c.Backend.SDKConfig == `` {
+			return fmt.Errorf(`%s: sdk config`, ErrConfigInvalid)
+		}
+		if _, err := c.Identity(c.Backend.Identity); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf(`%s: %s`, ErrUnknownBackend, c.Backend.Type)
+	}
+
+	for _, cc := range c.Chaincodes {
+		if cc.Name == `` || cc.Channel == `` || cc.Service == `` {
+			return fmt.Errorf(`%s: chaincode name, channel and service required`, ErrConfigInvalid)
+		}
+		if _, err := c.Identity(cc.Identity); err != nil {
+			return err
+		}
+	}
+
+	if c.Auth != nil {
+		if c.Auth.APIKeyHeader == `` && c.Auth.JWTSecretFile == `` && !c.Auth.ClientCert {
+			return fmt.Errorf(`%s: auth requires api key header, jwt secret or client cert`, ErrConfigInvalid)
+		}
+		for _, caller := range c.Auth.Callers {
+			if caller.Id == `` {
+				return fmt.Errorf(`%s: caller id required`, ErrConfigInvalid)
+			}
+			if _, err := c.Identity(caller.Identity); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ResolvePaths makes relative file paths in config relative to dir
+func (c *Config) ResolvePaths(dir string) {
+	resolve := func(path *string) {
+		if *path != `` && !filepath.IsAbs(*path) {
+			*path = filepath.Join(dir, *path)
+		}
+	}
+	resolveTLS := func(conf *TLSConfig) {
+		if conf != nil {
+			resolve(&conf.CertFile)
+			resolve(&conf.KeyFile)
+			resolve(&conf.ClientCAFile)
+		}
+	}
+
+	resolveTLS(c.GRPC.TLS)
+	resolveTLS(c.REST.TLS)
+	resolve(&c.Backend.SDKConfig)
+	for i := range c.Identities {
+		resolve(&c.Identities[i].CertFile)
+		resolve(&c.Identities[i].KeyFile)
+	}
+	if c.Auth != nil {
+		resolve(&c.Auth.JWTSecretFile)
+	}
+}
+
+// Identity returns identity config by name
+func (c *Config) Identity(name string) (*IdentityConfig, error) {
+	for i := range c.Identities {
+		if c.Identities[i].Name == name {
+			return &c.Identities[i], nil
+		}
+	}
+	return nil, fmt.Errorf(`%s: %s`, ErrIdentityNotDefined, name)
+}
@@ -0,0 +1,23 @@
+package server
+
+import "errors"
+
+var (
+	// ErrConfigInvalid occurs when required config value is missing or inconsistent
+	ErrConfigInvalid = errors.New(`config invalid`)
+
+	// ErrUnknownBackend occurs when backend type is neither mock nor sdk
+	ErrUnknownBackend = errors.New(`unknown backend`)
+
+	// ErrIdentityNotDefined occurs when backend, chaincode or caller config refers to identity, not defined in config
+	ErrIdentityNotDefined = errors.New(`identity not defined`)
+
+	// ErrServiceNotRegistered occurs when chaincode config refers to service, not registered with WithService
+	ErrServiceNotRegistered = errors.New(`service not registered`)
+
+	// ErrChaincodeNotRegistered occurs when mock backend chaincode is not registered with WithChaincode
+	ErrChaincodeNotRegistered = errors.New(`chaincode not registered`)
+
+	// ErrChaincodeInitFailed occurs when mock backend chaincode init returns error response
+	ErrChaincodeInitFailed = errors.New(`chaincode init failed`)
+)
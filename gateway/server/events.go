@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/optherium/cckit/gateway"
+	"github.com/optherium/cckit/gateway/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// eventsService exposes events of configured chaincodes as chaincode service, so remote gateway clients
+// (generated XxxGatewayClient) can subscribe to events. Chaincode methods are available only via generated services
+type eventsService struct {
+	service service.Chaincode
+	// channel, chaincode
+	chaincodes map[[2]string]bool
+	resolver   gateway.SignerResolver
+}
+
+func newEventsService(s service.Chaincode, chaincodes []ChaincodeConfig, resolver gateway.SignerResolver) *eventsService {
+	es := &eventsService{
+		service:    s,
+		chaincodes: make(map[[2]string]bool),
+		resolver:   resolver,
+	}
+	for _, cc := range chaincodes {
+		es.chaincodes[[2]string{cc.Channel, cc.Name}] = true
+	}
+	return es
+}
+
+// check rejects not configured chaincodes and, with auth configured, callers with no resolvable identity
+func (es *eventsService) check(ctx context.Context, channel, chaincode string) error {
+	if !es.chaincodes[[2]string{channel, chaincode}] {
+		return status.Errorf(codes.NotFound, `%s: %s/%s`, service.ErrChaincodeNotExists, channel, chaincode)
+	}
+	if es.resolver != nil {
+		if _, err := es.resolver.Resolve(ctx); err != nil {
+			return status.Errorf(codes.Unauthenticated, `%s: %s`, gateway.ErrSignerNotResolved, err)
+		}
+	}
+	return nil
+}
+
+func (es *eventsService) Events(in *service.ChaincodeLocator, stream service.Chaincode_EventsServer) error {
+	if err := es.check(stream.Context(), in.Channel, in.Chaincode); err != nil {
+		return err
+	}
+	return es.service.Events(in, stream)
+}
+
+func (es *eventsService) EventsStream(in *service.ChaincodeEventsStreamRequest, stream service.Chaincode_EventsStreamServer) error {
+	if err := es.check(stream.Context(), in.Channel, in.Chaincode); err != nil {
+		return err
+	}
+	return es.service.EventsStream(in, stream)
+}
+
+func (es *eventsService) Query(context.Context, *service.ChaincodeInput) (*peer.ProposalResponse, error) {
+	return nil, errMethodsNotExposed
+}
+
+func (es *eventsService) Invoke(context.Context, *service.ChaincodeInput) (*peer.ProposalResponse, error) {
+	return nil, errMethodsNotExposed
+}
+
+func (es *eventsService) Submit(context.Context, *service.ChaincodeInput) (*service.ChaincodeSubmitResponse, error) {
+	return nil, errMethodsNotExposed
+}
+
+func (es *eventsService) TxStatus(context.Context, *service.ChaincodeTxLocator) (*service.ChaincodeTxStatus, error) {
+	return nil, errMethodsNotExposed
+}
+
+var errMethodsNotExposed = status.Error(codes.Unimplemented, `chaincode methods are exposed via chaincode gateway services`)
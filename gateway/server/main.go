@@ -0,0 +1,36 @@
+package server
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// Main runs gateway server command: loads config from -config flag, serves until SIGINT or SIGTERM.
+// Generated services and in-process chaincodes are registered with opts
+func Main(opts ...Opt) {
+	configPath := flag.String(`config`, `gateway.yaml`, `gateway server config file`)
+	flag.Parse()
+
+	config, err := LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf(`load config %s: %s`, *configPath, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		log.Printf(`received signal %s`, <-signals)
+		cancel()
+	}()
+
+	if err = New(config, opts...).Run(ctx); err != nil {
+		log.Fatalln(err)
+	}
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+
+	"github.com/optherium/cckit/gateway"
+)
+
+// ErrProxyListenerClosed occurs when REST proxy dials gRPC server after server shutdown
+var ErrProxyListenerClosed = errors.New(`proxy listener closed`)
+
+type (
+	// proxyListener serves in-process connections of REST proxy to gRPC server. Remote address of connections
+	// is gateway.ProxyAddr, so REST proxy is not taken for gRPC client, regardless of gRPC listen address
+	proxyListener struct {
+		conns  chan net.Conn
+		closed chan struct{}
+		once   sync.Once
+	}
+
+	proxyConn struct {
+		net.Conn
+	}
+)
+
+func newProxyListener() *proxyListener {
+	return &proxyListener{
+		conns:  make(chan net.Conn),
+		closed: make(chan struct{}),
+	}
+}
+
+func (l *proxyListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.closed:
+		return nil, ErrProxyListenerClosed
+	}
+}
+
+func (l *proxyListener) Close() error {
+	l.once.Do(func() { close(l.closed) })
+	return nil
+}
+
+func (l *proxyListener) Addr() net.Addr {
+	return gateway.ProxyAddr{}
+}
+
+// DialContext creates in-process connection, used as grpc dialer of REST proxy
+func (l *proxyListener) DialContext(ctx context.Context, _ string) (net.Conn, error) {
+	server, client := net.Pipe()
+	select {
+	case l.conns <- &proxyConn{Conn: server}:
+		return &proxyConn{Conn: client}, nil
+	case <-l.closed:
+	case <-ctx.Done():
+	}
+	_ = server.Close()
+	_ = client.Close()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return nil, ErrProxyListenerClosed
+}
+
+func (c *proxyConn) LocalAddr() net.Addr {
+	return gateway.ProxyAddr{}
+}
+
+func (c *proxyConn) RemoteAddr() net.Addr {
+	return gateway.ProxyAddr{}
+}
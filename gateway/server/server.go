@@ -0,0 +1,302 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/optherium/cckit/gateway"
+	"github.com/optherium/cckit/gateway/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+type (
+	// ServiceDefFactory creates generated chaincode gateway service definition, i.e.
+	// func(...) gateway.ServiceDef { return NewCPaperGateway(ccService, channel, chaincode, opts...).ApiDef() }
+	ServiceDefFactory func(ccService service.Chaincode, channel, chaincode string, opts ...gateway.Opt) gateway.ServiceDef
+
+	// ChaincodeFactory creates in-process chaincode for mock backend
+	ChaincodeFactory func() (shim.Chaincode, error)
+
+	Opt func(*Server)
+
+	// Server exposes configured chaincodes as gRPC and REST (grpc-gateway) services
+	Server struct {
+		config     *Config
+		services   map[string]ServiceDefFactory
+		chaincodes map[string]ChaincodeFactory
+
+		grpcServer   *grpc.Server
+		grpcListener net.Listener
+		// in-process connections of REST proxy to gRPC server
+		proxyListener *proxyListener
+		restServer    *http.Server
+		restListener  net.Listener
+	}
+)
+
+// WithService registers generated service, chaincode config refers to service by name
+func WithService(name string, factory ServiceDefFactory) Opt {
+	return func(s *Server) {
+		s.services[name] = factory
+	}
+}
+
+// WithChaincode registers in-process chaincode for mock backend, by chaincode name
+func WithChaincode(name string, factory ChaincodeFactory) Opt {
+	return func(s *Server) {
+		s.chaincodes[name] = factory
+	}
+}
+
+func New(config *Config, opts ...Opt) *Server {
+	s := &Server{
+		config:     config,
+		services:   make(map[string]ServiceDefFactory),
+		chaincodes: make(map[string]ChaincodeFactory),
+	}
+	for _, o := range opts {
+		o(s)
+	}
+	return s
+}
+
+// Run listens and serves until ctx is done, then gracefully stops
+func (s *Server) Run(ctx context.Context) error {
+	if err := s.Listen(ctx); err != nil {
+		return err
+	}
+	return s.Serve(ctx)
+}
+
+// Listen creates backend, chaincode gateways and listeners
+func (s *Server) Listen(ctx context.Context) error {
+	b, err := newBackend(s.config, s.chaincodes)
+	if err != nil {
+		return err
+	}
+
+	var resolver gateway.SignerResolver
+	if s.config.Auth != nil {
+		if resolver, err = signerResolver(s.config.Auth, b); err != nil {
+			return err
+		}
+	}
+
+	var defs []gateway.ServiceDef
+	for _, ccConf := range s.config.Chaincodes {
+		factory, ok := s.services[ccConf.Service]
+		if !ok {
+			return fmt.Errorf(`%s: %s`, ErrServiceNotRegistered, ccConf.Service)
+		}
+
+		var opt gateway.Opt
+		if resolver != nil {
+			opt = gateway.WithSignerResolver(resolver)
+		} else {
+			signer, err := b.Signer(ccConf.Identity)
+			if err != nil {
+				return err
+			}
+			opt = gateway.WithDefaultSigner(signer)
+		}
+		defs = append(defs, factory(b.Service(), ccConf.Channel, ccConf.Name, opt))
+	}
+
+	if err = s.listenGRPC(defs, newEventsService(b.Service(), s.config.Chaincodes, resolver)); err != nil {
+		return err
+	}
+	if s.config.REST.Address == `` {
+		return nil
+	}
+	if err = s.listenREST(ctx, defs); err != nil {
+		// gRPC server is not served, so listener is released here
+		_ = s.grpcListener.Close()
+		return err
+	}
+	return nil
+}
+
+func (s *Server) listenGRPC(defs []gateway.ServiceDef, events service.Chaincode) (err error) {
+	// chaincode errors are carried in status details, so remote clients decode them back to CCError
+	opts := []grpc.ServerOption{grpc.UnaryInterceptor(service.UnaryServerErrorInterceptor)}
+	if s.config.GRPC.TLS != nil {
+		tlsConfig, err := serverTLSConfig(s.config.GRPC.TLS)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	s.grpcServer = grpc.NewServer(opts...)
+	for _, def := range defs {
+		s.grpcServer.RegisterService(def.Desc, def.Service)
+	}
+	service.RegisterChaincodeServer(s.grpcServer, events)
+
+	if s.grpcListener, err = net.Listen(`tcp`, s.config.GRPC.Address); err != nil {
+		return fmt.Errorf(`listen grpc: %s`, err)
+	}
+	return nil
+}
+
+// listenREST registers grpc-gateway handlers, proxying calls to gRPC server via in-process connections
+func (s *Server) listenREST(ctx context.Context, defs []gateway.ServiceDef) (err error) {
+	s.proxyListener = newProxyListener()
+	dialOpts := []grpc.DialOption{grpc.WithContextDialer(s.proxyListener.DialContext), grpc.WithInsecure()}
+	if s.config.GRPC.TLS != nil {
+		creds, err := proxyCredentials(s.config.GRPC.TLS)
+		if err != nil {
+			return err
+		}
+		dialOpts = []grpc.DialOption{
+			grpc.WithContextDialer(s.proxyListener.DialContext), grpc.WithTransportCredentials(creds)}
+	}
+
+	mux := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(s.headerMatcher))
+	for _, def := range defs {
+		if def.HandlerFromEndpointRegister == nil {
+			continue
+		}
+		if err = def.HandlerFromEndpointRegister(ctx, mux, s.grpcListener.Addr().String(), dialOpts); err != nil {
+			return fmt.Errorf(`register REST handler: %s`, err)
+		}
+	}
+
+	s.restServer = &http.Server{Handler: mux}
+	if s.config.REST.TLS != nil {
+		if s.restServer.TLSConfig, err = serverTLSConfig(s.config.REST.TLS); err != nil {
+			return err
+		}
+	}
+
+	if s.restListener, err = net.Listen(`tcp`, s.config.REST.Address); err != nil {
+		return fmt.Errorf(`listen REST: %s`, err)
+	}
+	return nil
+}
+
+// Serve serves gRPC and REST until ctx is done or server fails, then gracefully stops both
+func (s *Server) Serve(ctx context.Context) error {
+	errs := make(chan error, 3)
+
+	go func() {
+		log.Printf(`listen gRPC at %s`, s.grpcListener.Addr())
+		errs <- s.grpcServer.Serve(s.grpcListener)
+	}()
+
+	if s.restServer != nil {
+		go func() {
+			errs <- s.grpcServer.Serve(s.proxyListener)
+		}()
+
+		go func() {
+			log.Printf(`listen REST at %s`, s.restListener.Addr())
+			var err error
+			if s.restServer.TLSConfig != nil {
+				err = s.restServer.ServeTLS(s.restListener, ``, ``)
+			} else {
+				err = s.restServer.Serve(s.restListener)
+			}
+			if err != http.ErrServerClosed {
+				errs <- err
+			}
+		}()
+	}
+
+	var err error
+	select {
+	case <-ctx.Done():
+	case err = <-errs:
+	}
+
+	s.shutdown()
+	return err
+}
+
+func (s *Server) shutdown() {
+	log.Println(`gateway server shutdown`)
+	if s.restServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
+		defer cancel()
+		if err := s.restServer.Shutdown(ctx); err != nil {
+			log.Printf(`REST server shutdown: %s`, err)
+		}
+	}
+	s.grpcServer.GracefulStop()
+}
+
+// GRPCAddr returns gRPC listener address, available after Listen
+func (s *Server) GRPCAddr() net.Addr {
+	return s.grpcListener.Addr()
+}
+
+// RESTAddr returns REST listener address, available after Listen if REST address configured
+func (s *Server) RESTAddr() net.Addr {
+	if s.restListener == nil {
+		return nil
+	}
+	return s.restListener.Addr()
+}
+
+func serverTLSConfig(conf *TLSConfig) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(conf.CertFile, conf.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}}
+
+	if conf.ClientCAFile != `` {
+		if tlsConfig.ClientCAs, err = certPool(conf.ClientCAFile); err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
+
+// proxyCredentials - REST proxy trusts gRPC server certificate and presents it as client certificate,
+// so with client CA defined server certificate must be issued by client CA
+func proxyCredentials(conf *TLSConfig) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(conf.CertFile, conf.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, err
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(leaf)
+
+	serverName := leaf.Subject.CommonName
+	if len(leaf.DNSNames) > 0 {
+		serverName = leaf.DNSNames[0]
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      roots,
+		ServerName:   serverName,
+	}), nil
+}
+
+func certPool(file string) (*x509.CertPool, error) {
+	pem, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf(`no certificates in %s`, file)
+	}
+	return pool, nil
+}
@@ -0,0 +1,352 @@
+package server_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	ccerrors "github.com/optherium/cckit/errors"
+	"github.com/optherium/cckit/examples/cpaper_asservice"
+	"github.com/optherium/cckit/examples/cpaper_asservice/schema"
+	cpaperservice "github.com/optherium/cckit/examples/cpaper_asservice/service"
+	"github.com/optherium/cckit/gateway"
+	"github.com/optherium/cckit/gateway/server"
+	"github.com/optherium/cckit/gateway/service"
+	testcc "github.com/optherium/cckit/testing"
+)
+
+func TestServer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Gateway server suite")
+}
+
+const configYaml = `
+grpc:
+  address: 127.0.0.1:0
+rest:
+  address: 127.0.0.1:0
+backend:
+  type: mock
+identities:
+  - name: admin
+    msp_id: MSP
+    cert_file: ../../examples/cpaper_asservice/testdata/admin.pem
+chaincodes:
+  - name: cpaper
+    channel: cpaper
+    service: cpaper
+    identity: admin
+`
+
+var opts = []server.Opt{
+	server.WithService(`cpaper`, func(ccService service.Chaincode, channel, chaincode string, opts ...gateway.Opt) gateway.ServiceDef {
+		return cpaperservice.NewCPaperGateway(ccService, channel, chaincode, opts...).ApiDef()
+	}),
+	server.WithChaincode(`cpaper`, func() (shim.Chaincode, error) {
+		return cpaper_asservice.NewCC()
+	}),
+}
+
+func issue(number string) *schema.IssueCommercialPaper {
+	return &schema.IssueCommercialPaper{
+		Issuer:       `SomeIssuer`,
+		PaperNumber:  number,
+		IssueDate:    testcc.MustProtoTimestamp(time.Now()),
+		MaturityDate: testcc.MustProtoTimestamp(time.Now().AddDate(0, 2, 0)),
+		FaceValue:    100000,
+		ExternalId:   `EXT` + number,
+	}
+}
+
+// start runs server, returns func, stopping server and returning Run error
+func start(config *server.Config) (*server.Server, func() error) {
+	s := server.New(config, opts...)
+	ctx, cancel := context.WithCancel(context.Background())
+	Expect(s.Listen(ctx)).To(Succeed())
+
+	done := make(chan error, 1)
+	go func() { done <- s.Serve(ctx) }()
+
+	return s, func() error {
+		cancel()
+		return <-done
+	}
+}
+
+// selfSigned writes self-signed certificate for 127.0.0.1 and key to dir
+func selfSigned(dir string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: `localhost`},
+		DNSNames:              []string{`localhost`},
+		IPAddresses:           []net.IP{net.ParseIP(`127.0.0.1`)},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	Expect(err).NotTo(HaveOccurred())
+
+	certFile, keyFile = filepath.Join(dir, `cert.pem`), filepath.Join(dir, `key.pem`)
+	Expect(ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: `CERTIFICATE`, Bytes: der}), 0600)).To(Succeed())
+	Expect(ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: `EC PRIVATE KEY`, Bytes: keyDer}), 0600)).To(Succeed())
+	return
+}
+
+var _ = Describe(`Gateway server`, func() {
+
+	Describe(`Config`, func() {
+
+		It(`Allow to parse config`, func() {
+			config, err := server.ParseConfig([]byte(configYaml))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Backend.Type).To(Equal(server.BackendMock))
+			Expect(config.Chaincodes).To(HaveLen(1))
+			Expect(config.ShutdownTimeout).To(Equal(server.DefaultShutdownTimeout))
+		})
+
+		It(`Disallow unknown backend`, func() {
+			_, err := server.ParseConfig([]byte(`{grpc: {address: ":0"}, backend: {type: unknown}}`))
+			Expect(err).To(MatchError(ContainSubstring(server.ErrUnknownBackend.Error())))
+		})
+
+		It(`Disallow chaincode with undefined identity`, func() {
+			_, err := server.ParseConfig([]byte(`{grpc: {address: ":0"}, backend: {type: mock},
+				chaincodes: [{name: cc, channel: ch, service: svc, identity: unknown}]}`))
+			Expect(err).To(MatchError(ContainSubstring(server.ErrIdentityNotDefined.Error())))
+		})
+
+		It(`Disallow auth without caller credentials source`, func() {
+			_, err := server.ParseConfig([]byte(configYaml + `
+auth:
+  callers: [{id: key, identity: admin}]
+`))
+			Expect(err).To(MatchError(ContainSubstring(server.ErrConfigInvalid.Error())))
+		})
+
+		It(`Disallow not registered service`, func() {
+			config, err := server.ParseConfig([]byte(configYaml))
+			Expect(err).NotTo(HaveOccurred())
+			config.Chaincodes[0].Service = `unknown`
+
+			err = server.New(config, opts...).Listen(context.Background())
+			Expect(err).To(MatchError(ContainSubstring(server.ErrServiceNotRegistered.Error())))
+		})
+
+		It(`Allow to load config with file paths relative to config file dir`, func() {
+			dir, err := ioutil.TempDir(``, `gateway`)
+			Expect(err).NotTo(HaveOccurred())
+			defer func() { _ = os.RemoveAll(dir) }()
+
+			configFile := filepath.Join(dir, `gateway.yaml`)
+			Expect(ioutil.WriteFile(configFile, []byte(configYaml+`
+auth:
+  jwt_secret_file: /etc/secret
+  client_cert: true
+`), 0600)).To(Succeed())
+
+			config, err := server.LoadConfig(configFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Identities[0].CertFile).To(Equal(
+				filepath.Join(dir, `../../examples/cpaper_asservice/testdata/admin.pem`)))
+			Expect(config.Auth.JWTSecretFile).To(Equal(`/etc/secret`))
+		})
+
+		It(`Allow to release gRPC listener if REST listen failed`, func() {
+			busy, err := net.Listen(`tcp`, `127.0.0.1:0`)
+			Expect(err).NotTo(HaveOccurred())
+			defer func() { _ = busy.Close() }()
+
+			config, err := server.ParseConfig([]byte(configYaml))
+			Expect(err).NotTo(HaveOccurred())
+			config.REST.Address = busy.Addr().String()
+
+			s := server.New(config, opts...)
+			Expect(s.Listen(context.Background())).To(MatchError(ContainSubstring(`listen REST`)))
+
+			grpcListener, err := net.Listen(`tcp`, s.GRPCAddr().String())
+			Expect(err).NotTo(HaveOccurred())
+			_ = grpcListener.Close()
+		})
+	})
+
+	Describe(`Mock backend`, func() {
+
+		It(`Allow to invoke via gRPC and query via REST`, func() {
+			config, err := server.ParseConfig([]byte(configYaml))
+			Expect(err).NotTo(HaveOccurred())
+			s, stop := start(config)
+
+			conn, err := grpc.Dial(s.GRPCAddr().String(), grpc.WithInsecure())
+			Expect(err).NotTo(HaveOccurred())
+			defer func() { _ = conn.Close() }()
+
+			cpaper, err := cpaperservice.NewCPaperClient(conn).Issue(context.Background(), issue(`0001`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cpaper.PaperNumber).To(Equal(`0001`))
+
+			list, err := cpaperservice.NewCPaperClient(conn).List(context.Background(), &empty.Empty{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.Items).To(HaveLen(1))
+
+			res, err := http.Get(`http://` + s.RESTAddr().String() + `/cpaper/SomeIssuer/0001`)
+			Expect(err).NotTo(HaveOccurred())
+			body, _ := ioutil.ReadAll(res.Body)
+			_ = res.Body.Close()
+			Expect(res.StatusCode).To(Equal(http.StatusOK))
+			Expect(string(body)).To(ContainSubstring(`EXT0001`))
+
+			Expect(stop()).To(Succeed())
+		})
+
+		It(`Allow to call chaincode and subscribe to typed events via remote gateway client`, func() {
+			config, err := server.ParseConfig([]byte(configYaml))
+			Expect(err).NotTo(HaveOccurred())
+			s, stop := start(config)
+
+			conn, err := grpc.Dial(s.GRPCAddr().String(), grpc.WithInsecure())
+			Expect(err).NotTo(HaveOccurred())
+			defer func() { _ = conn.Close() }()
+
+			var remote cpaperservice.CPaperGatewayInterface = cpaperservice.NewCPaperGatewayClient(conn, `cpaper`, `cpaper`,
+				gateway.WithEventTypes(gateway.NewEventTypes(&schema.IssueCommercialPaper{})))
+
+			_, err = remote.Issue(context.Background(), issue(`0004`))
+			Expect(err).NotTo(HaveOccurred())
+			cpaper, err := remote.Get(context.Background(), &schema.CommercialPaperId{Issuer: `SomeIssuer`, PaperNumber: `0004`})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cpaper.ExternalId).To(Equal(`EXT0004`))
+
+			// chaincode error is decoded from gRPC status details
+			_, err = remote.Issue(context.Background(), issue(`0004`))
+			ccErr, ok := ccerrors.AsCCError(err)
+			Expect(ok).To(BeTrue())
+			Expect(ccErr.Code).To(Equal(int32(shim.ERROR)))
+			Expect(ccErr.Message).To(ContainSubstring(ccerrors.ErrKeyAlreadyExists.Error()))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			sub, err := remote.Events(ctx, gateway.WithBlockRange(1, 0))
+			Expect(err).NotTo(HaveOccurred())
+
+			var e *gateway.TypedEvent
+			Eventually(sub.TypedEvents()).Should(Receive(&e))
+			Expect(e.Name).To(Equal(`IssueCommercialPaper`))
+			Expect(e.Message.(*schema.IssueCommercialPaper).PaperNumber).To(Equal(`0004`))
+			sub.Close()
+
+			// events only of configured chaincodes are exposed
+			unknown, err := cpaperservice.NewCPaperGatewayClient(conn, `cpaper`, `unknown`).Events(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(unknown.Events()).Should(BeClosed())
+			Expect(status.Code(unknown.Err())).To(Equal(codes.NotFound))
+
+			Expect(stop()).To(Succeed())
+		})
+
+		It(`Allow to resolve caller identity by API key`, func() {
+			config, err := server.ParseConfig([]byte(configYaml + `
+auth:
+  api_key_header: X-Api-Key
+  callers: [{id: admin-key, identity: admin}]
+`))
+			Expect(err).NotTo(HaveOccurred())
+			s, stop := start(config)
+
+			conn, err := grpc.Dial(s.GRPCAddr().String(), grpc.WithInsecure())
+			Expect(err).NotTo(HaveOccurred())
+			defer func() { _ = conn.Close() }()
+
+			_, err = cpaperservice.NewCPaperClient(conn).Issue(context.Background(), issue(`0003`))
+			Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+
+			ctx := metadata.AppendToOutgoingContext(context.Background(), `x-api-key`, `admin-key`)
+			_, err = cpaperservice.NewCPaperClient(conn).Issue(ctx, issue(`0003`))
+			Expect(err).NotTo(HaveOccurred())
+
+			url := `http://` + s.RESTAddr().String() + `/cpaper/SomeIssuer/0003`
+			res, err := http.Get(url)
+			Expect(err).NotTo(HaveOccurred())
+			_ = res.Body.Close()
+			Expect(res.StatusCode).To(Equal(http.StatusUnauthorized))
+
+			req, _ := http.NewRequest(http.MethodGet, url, nil)
+			req.Header.Set(`X-Api-Key`, `admin-key`)
+			res, err = http.DefaultClient.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			body, _ := ioutil.ReadAll(res.Body)
+			_ = res.Body.Close()
+			Expect(res.StatusCode).To(Equal(http.StatusOK))
+			Expect(string(body)).To(ContainSubstring(`EXT0003`))
+
+			Expect(stop()).To(Succeed())
+		})
+
+		It(`Allow to serve with TLS`, func() {
+			dir, err := ioutil.TempDir(``, `gateway-server`)
+			Expect(err).NotTo(HaveOccurred())
+			defer func() { _ = os.RemoveAll(dir) }()
+			certFile, keyFile := selfSigned(dir)
+
+			config, err := server.ParseConfig([]byte(configYaml))
+			Expect(err).NotTo(HaveOccurred())
+			tlsConf := &server.TLSConfig{CertFile: certFile, KeyFile: keyFile}
+			config.GRPC.TLS, config.REST.TLS = tlsConf, tlsConf
+			s, stop := start(config)
+
+			creds, err := credentials.NewClientTLSFromFile(certFile, `localhost`)
+			Expect(err).NotTo(HaveOccurred())
+			conn, err := grpc.Dial(s.GRPCAddr().String(), grpc.WithTransportCredentials(creds))
+			Expect(err).NotTo(HaveOccurred())
+			defer func() { _ = conn.Close() }()
+
+			_, err = cpaperservice.NewCPaperClient(conn).Issue(context.Background(), issue(`0002`))
+			Expect(err).NotTo(HaveOccurred())
+
+			pool := x509.NewCertPool()
+			certPEM, _ := ioutil.ReadFile(certFile)
+			pool.AppendCertsFromPEM(certPEM)
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}}
+
+			res, err := client.Get(`https://` + s.RESTAddr().String() + `/cpaper`)
+			Expect(err).NotTo(HaveOccurred())
+			body, _ := ioutil.ReadAll(res.Body)
+			_ = res.Body.Close()
+			Expect(res.StatusCode).To(Equal(http.StatusOK))
+			Expect(string(body)).To(ContainSubstring(`EXT0002`))
+
+			Expect(stop()).To(Succeed())
+		})
+	})
+})
@@ -2,26 +2,57 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"math"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 	"github.com/s7techlab/hlf-sdk-go/api"
+	sdkpeer "github.com/s7techlab/hlf-sdk-go/peer"
 )
 
 type (
 	// Chaincode service interface
-	Chaincode             = ChaincodeServer
-	ChaincodeEventsServer = chaincodeEventsServer
+	Chaincode                   = ChaincodeServer
+	ChaincodeEventsServer       = chaincodeEventsServer
+	ChaincodeEventsStreamServer = chaincodeEventsStreamServer
 )
 
 // ChaincodeService implementation based of hlf-sdk-go
 type ChaincodeService struct {
 	sdk api.Core
+
+	// used by Submit, api.Core doesn't expose them
+	orderer   api.Orderer
+	discovery api.DiscoveryProvider
+}
+
+// Opt sets ChaincodeService option
+type Opt func(*ChaincodeService)
+
+// WithSubmitter sets orderer and discovery provider, used for sending transactions by Submit
+// without waiting for commit
+func WithSubmitter(orderer api.Orderer, discovery api.DiscoveryProvider) Opt {
+	return func(cs *ChaincodeService) {
+		cs.orderer = orderer
+		cs.discovery = discovery
+	}
 }
 
-func New(sdk api.Core) *ChaincodeService {
-	return &ChaincodeService{sdk: sdk}
+func New(sdk api.Core, opts ...Opt) *ChaincodeService {
+	cs := &ChaincodeService{sdk: sdk}
+	for _, o := range opts {
+		o(cs)
+	}
+	return cs
+}
+
+// CanSubmit returns true if service is created with WithSubmitter, so Submit can send transactions
+func (cs *ChaincodeService) CanSubmit() bool {
+	return cs.orderer != nil && cs.discovery != nil
 }
 
 func (cs *ChaincodeService) Invoke(ctx context.Context, in *ChaincodeInput) (*peer.ProposalResponse, error) {
@@ -40,7 +71,7 @@ func (cs *ChaincodeService) Invoke(ctx context.Context, in *ChaincodeInput) (*pe
 		Do(ctx)
 
 	if err != nil {
-		return nil, err
+		return nil, endorseError(err)
 	}
 
 	// todo: add to hlf-sdk-go method returning ProposalResponse
@@ -50,6 +81,103 @@ func (cs *ChaincodeService) Invoke(ctx context.Context, in *ChaincodeInput) (*pe
 	return proposalResponse, nil
 }
 
+// Submit sends endorsed transaction to orderer and returns without waiting for commit,
+// requires orderer and discovery provider, set with WithSubmitter
+func (cs *ChaincodeService) Submit(ctx context.Context, in *ChaincodeInput) (*ChaincodeSubmitResponse, error) {
+	if !cs.CanSubmit() {
+		return nil, ErrSubmitterNotDefined
+	}
+
+	signer, err := SignerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cc, err := cs.discovery.Chaincode(in.Channel, in.Chaincode)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get chaincode definition`)
+	}
+
+	// the same steps as hlf-sdk-go invoke, without commit subscription
+	processor := sdkpeer.NewProcessor(in.Channel)
+	signedProposal, tx, err := processor.CreateProposal(cc, signer, string(in.Args[0]), in.Args[1:], in.Transient)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get signed proposal`)
+	}
+
+	responses, err := processor.Send(ctx, signedProposal, cc, cs.sdk.PeerPool())
+	if err != nil {
+		return nil, endorseError(err)
+	}
+
+	proposal := &peer.Proposal{}
+	if err = proto.Unmarshal(signedProposal.ProposalBytes, proposal); err != nil {
+		return nil, errors.Wrap(err, `failed to unmarshal proposal`)
+	}
+
+	envelope, err := utils.CreateSignedTx(proposal, signer, responses...)
+	if err != nil {
+		return nil, errors.Wrap(err, `failed to get envelope`)
+	}
+
+	if _, err = cs.orderer.Broadcast(ctx, envelope); err != nil {
+		return nil, errors.Wrap(err, `failed to get orderer response`)
+	}
+
+	return &ChaincodeSubmitResponse{
+		TxId:     string(tx),
+		Response: responses[0],
+	}, nil
+}
+
+// TxStatus waits for transaction commit and returns block number and validation code,
+// blocks are delivered to signer from context
+func (cs *ChaincodeService) TxStatus(ctx context.Context, in *ChaincodeTxLocator) (*ChaincodeTxStatus, error) {
+	signer, err := SignerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deliver, err := cs.sdk.PeerPool().DeliverClient(signer.GetMSPIdentifier(), signer)
+	if err != nil {
+		return nil, err
+	}
+
+	// subscribe before ledger lookup, so tx committed in between is not missed
+	blocks, err := deliver.SubscribeBlock(ctx, in.Channel, api.SeekNewest())
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = blocks.Close() }()
+
+	if block, err := cs.sdk.System().QSCC().GetBlockByTxID(ctx, in.Channel, api.ChaincodeTx(in.TxId)); err == nil {
+		if status, err := BlockTxStatus(block, in.TxId); err != nil || status != nil {
+			return status, err
+		}
+	}
+
+	for {
+		select {
+		case block, ok := <-blocks.Blocks():
+			if !ok {
+				return nil, fmt.Errorf(`%s: %s`, ErrTxNotFound, in.TxId)
+			}
+			if status, err := BlockTxStatus(block, in.TxId); err != nil || status != nil {
+				return status, err
+			}
+
+		case err, ok := <-blocks.Errors():
+			if ok {
+				return nil, err
+			}
+			return nil, fmt.Errorf(`%s: %s`, ErrTxNotFound, in.TxId)
+
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
 func (cs *ChaincodeService) Query(ctx context.Context, in *ChaincodeInput) (*peer.ProposalResponse, error) {
 	argSs := make([]string, 0)
 	for _, arg := range in.Args {
@@ -62,6 +190,9 @@ func (cs *ChaincodeService) Query(ctx context.Context, in *ChaincodeInput) (*pee
 	}
 
 	if resp, err := cs.sdk.Channel(in.Channel).Chaincode(in.Chaincode).Query(argSs[0], argSs[1:]...).WithIdentity(signer).Transient(in.Transient).AsProposalResponse(ctx); err != nil {
+		if ccErr := endorseError(err); ccErr != err {
+			return nil, ccErr
+		}
 		return nil, errors.Wrap(err, `failed to query chaincode`)
 	} else {
 		return resp, nil
@@ -92,3 +223,65 @@ func (cs *ChaincodeService) Events(in *ChaincodeLocator, stream Chaincode_Events
 		}
 	}
 }
+
+func (cs *ChaincodeService) EventsStream(in *ChaincodeEventsStreamRequest, stream Chaincode_EventsStreamServer) error {
+	filter, err := NewEventsStreamFilter(in)
+	if err != nil {
+		return err
+	}
+
+	deliver, err := cs.sdk.PeerPool().DeliverClient(cs.sdk.CurrentIdentity().GetMSPIdentifier(), cs.sdk.CurrentIdentity())
+	if err != nil {
+		return err
+	}
+
+	seek := api.SeekNewest()
+	if start := filter.StartBlock(); start > 0 {
+		stop := filter.ToBlock()
+		if stop == 0 {
+			stop = math.MaxUint64
+		}
+		seek = api.SeekRange(start, stop)
+	}
+
+	blocks, err := deliver.SubscribeBlock(stream.Context(), in.Channel, seek)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = blocks.Close() }()
+
+	for {
+		select {
+		case block, ok := <-blocks.Blocks():
+			if !ok || filter.Done(block.Header.Number) {
+				return nil
+			}
+
+			events, err := BlockChaincodeEvents(block, in.Chaincode)
+			if err != nil {
+				return err
+			}
+			for _, e := range events {
+				if !filter.Match(e) {
+					continue
+				}
+				if err = stream.Send(e); err != nil {
+					return err
+				}
+			}
+
+			if filter.ToBlock() > 0 && block.Header.Number >= filter.ToBlock() {
+				return nil
+			}
+
+		case err, ok := <-blocks.Errors():
+			if ok {
+				return err
+			}
+			return nil
+
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
@@ -142,37 +142,416 @@ func (m *ChaincodeLocator) GetChannel() string {
 	return ""
 }
 
+type ChaincodeEventsStreamRequest struct {
+	// Chaincode name
+	Chaincode string `protobuf:"bytes,1,opt,name=chaincode,proto3" json:"chaincode,omitempty"`
+	// Channel name
+	Channel string `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	// Event name filters, event matches if its name equals one of event names or matches one of regexps.
+	// All events are streamed if no filters defined
+	EventNames       []string `protobuf:"bytes,3,rep,name=event_names,json=eventNames,proto3" json:"event_names,omitempty"`
+	EventNameRegexps []string `protobuf:"bytes,4,rep,name=event_name_regexps,json=eventNameRegexps,proto3" json:"event_name_regexps,omitempty"`
+	// First block of stream (inclusive), if not defined - stream starts from new blocks
+	FromBlock uint64 `protobuf:"varint,5,opt,name=from_block,json=fromBlock,proto3" json:"from_block,omitempty"`
+	// Last block of stream (inclusive), if not defined - stream is not limited
+	ToBlock uint64 `protobuf:"varint,6,opt,name=to_block,json=toBlock,proto3" json:"to_block,omitempty"`
+	// Stream resumes after checkpoint event, takes precedence over from_block
+	Checkpoint           *ChaincodeEventCheckpoint `protobuf:"bytes,7,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *ChaincodeEventsStreamRequest) Reset()         { *m = ChaincodeEventsStreamRequest{} }
+func (m *ChaincodeEventsStreamRequest) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeEventsStreamRequest) ProtoMessage()    {}
+func (*ChaincodeEventsStreamRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_97136ef4b384cc22, []int{2}
+}
+
+func (m *ChaincodeEventsStreamRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeEventsStreamRequest.Unmarshal(m, b)
+}
+func (m *ChaincodeEventsStreamRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeEventsStreamRequest.Marshal(b, m, deterministic)
+}
+func (m *ChaincodeEventsStreamRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeEventsStreamRequest.Merge(m, src)
+}
+func (m *ChaincodeEventsStreamRequest) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeEventsStreamRequest.Size(m)
+}
+func (m *ChaincodeEventsStreamRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeEventsStreamRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeEventsStreamRequest proto.InternalMessageInfo
+
+func (m *ChaincodeEventsStreamRequest) GetChaincode() string {
+	if m != nil {
+		return m.Chaincode
+	}
+	return ""
+}
+
+func (m *ChaincodeEventsStreamRequest) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *ChaincodeEventsStreamRequest) GetEventNames() []string {
+	if m != nil {
+		return m.EventNames
+	}
+	return nil
+}
+
+func (m *ChaincodeEventsStreamRequest) GetEventNameRegexps() []string {
+	if m != nil {
+		return m.EventNameRegexps
+	}
+	return nil
+}
+
+func (m *ChaincodeEventsStreamRequest) GetFromBlock() uint64 {
+	if m != nil {
+		return m.FromBlock
+	}
+	return 0
+}
+
+func (m *ChaincodeEventsStreamRequest) GetToBlock() uint64 {
+	if m != nil {
+		return m.ToBlock
+	}
+	return 0
+}
+
+func (m *ChaincodeEventsStreamRequest) GetCheckpoint() *ChaincodeEventCheckpoint {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return nil
+}
+
+// Position of chaincode event in ledger
+type ChaincodeEventCheckpoint struct {
+	// Block number
+	Block uint64 `protobuf:"varint,1,opt,name=block,proto3" json:"block,omitempty"`
+	// Transaction position in block
+	TxIndex              uint64   `protobuf:"varint,2,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChaincodeEventCheckpoint) Reset()         { *m = ChaincodeEventCheckpoint{} }
+func (m *ChaincodeEventCheckpoint) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeEventCheckpoint) ProtoMessage()    {}
+func (*ChaincodeEventCheckpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_97136ef4b384cc22, []int{3}
+}
+
+func (m *ChaincodeEventCheckpoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeEventCheckpoint.Unmarshal(m, b)
+}
+func (m *ChaincodeEventCheckpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeEventCheckpoint.Marshal(b, m, deterministic)
+}
+func (m *ChaincodeEventCheckpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeEventCheckpoint.Merge(m, src)
+}
+func (m *ChaincodeEventCheckpoint) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeEventCheckpoint.Size(m)
+}
+func (m *ChaincodeEventCheckpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeEventCheckpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeEventCheckpoint proto.InternalMessageInfo
+
+func (m *ChaincodeEventCheckpoint) GetBlock() uint64 {
+	if m != nil {
+		return m.Block
+	}
+	return 0
+}
+
+func (m *ChaincodeEventCheckpoint) GetTxIndex() uint64 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
+type ChaincodeEvent struct {
+	Event *peer.ChaincodeEvent `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	// Event position, can be used for resuming stream
+	Checkpoint           *ChaincodeEventCheckpoint `protobuf:"bytes,2,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *ChaincodeEvent) Reset()         { *m = ChaincodeEvent{} }
+func (m *ChaincodeEvent) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeEvent) ProtoMessage()    {}
+func (*ChaincodeEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_97136ef4b384cc22, []int{4}
+}
+
+func (m *ChaincodeEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeEvent.Unmarshal(m, b)
+}
+func (m *ChaincodeEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeEvent.Marshal(b, m, deterministic)
+}
+func (m *ChaincodeEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeEvent.Merge(m, src)
+}
+func (m *ChaincodeEvent) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeEvent.Size(m)
+}
+func (m *ChaincodeEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeEvent proto.InternalMessageInfo
+
+func (m *ChaincodeEvent) GetEvent() *peer.ChaincodeEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *ChaincodeEvent) GetCheckpoint() *ChaincodeEventCheckpoint {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return nil
+}
+
+// Transaction locator
+type ChaincodeTxLocator struct {
+	// Channel name
+	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	// Transaction id
+	TxId                 string   `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChaincodeTxLocator) Reset()         { *m = ChaincodeTxLocator{} }
+func (m *ChaincodeTxLocator) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeTxLocator) ProtoMessage()    {}
+func (*ChaincodeTxLocator) Descriptor() ([]byte, []int) {
+	return fileDescriptor_97136ef4b384cc22, []int{5}
+}
+
+func (m *ChaincodeTxLocator) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeTxLocator.Unmarshal(m, b)
+}
+func (m *ChaincodeTxLocator) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeTxLocator.Marshal(b, m, deterministic)
+}
+func (m *ChaincodeTxLocator) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeTxLocator.Merge(m, src)
+}
+func (m *ChaincodeTxLocator) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeTxLocator.Size(m)
+}
+func (m *ChaincodeTxLocator) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeTxLocator.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeTxLocator proto.InternalMessageInfo
+
+func (m *ChaincodeTxLocator) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *ChaincodeTxLocator) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+// Chaincode invoke, submitted to orderer
+type ChaincodeSubmitResponse struct {
+	// Transaction id
+	TxId string `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	// Endorsement response
+	Response             *peer.ProposalResponse `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *ChaincodeSubmitResponse) Reset()         { *m = ChaincodeSubmitResponse{} }
+func (m *ChaincodeSubmitResponse) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeSubmitResponse) ProtoMessage()    {}
+func (*ChaincodeSubmitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_97136ef4b384cc22, []int{6}
+}
+
+func (m *ChaincodeSubmitResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeSubmitResponse.Unmarshal(m, b)
+}
+func (m *ChaincodeSubmitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeSubmitResponse.Marshal(b, m, deterministic)
+}
+func (m *ChaincodeSubmitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeSubmitResponse.Merge(m, src)
+}
+func (m *ChaincodeSubmitResponse) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeSubmitResponse.Size(m)
+}
+func (m *ChaincodeSubmitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeSubmitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeSubmitResponse proto.InternalMessageInfo
+
+func (m *ChaincodeSubmitResponse) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *ChaincodeSubmitResponse) GetResponse() *peer.ProposalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+// Status of committed transaction
+type ChaincodeTxStatus struct {
+	// Transaction id
+	TxId string `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	// Number of block with transaction
+	Block uint64 `protobuf:"varint,2,opt,name=block,proto3" json:"block,omitempty"`
+	// Transaction validation code
+	ValidationCode       peer.TxValidationCode `protobuf:"varint,3,opt,name=validation_code,json=validationCode,proto3,enum=protos.TxValidationCode" json:"validation_code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ChaincodeTxStatus) Reset()         { *m = ChaincodeTxStatus{} }
+func (m *ChaincodeTxStatus) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeTxStatus) ProtoMessage()    {}
+func (*ChaincodeTxStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_97136ef4b384cc22, []int{7}
+}
+
+func (m *ChaincodeTxStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeTxStatus.Unmarshal(m, b)
+}
+func (m *ChaincodeTxStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeTxStatus.Marshal(b, m, deterministic)
+}
+func (m *ChaincodeTxStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeTxStatus.Merge(m, src)
+}
+func (m *ChaincodeTxStatus) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeTxStatus.Size(m)
+}
+func (m *ChaincodeTxStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeTxStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeTxStatus proto.InternalMessageInfo
+
+func (m *ChaincodeTxStatus) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *ChaincodeTxStatus) GetBlock() uint64 {
+	if m != nil {
+		return m.Block
+	}
+	return 0
+}
+
+func (m *ChaincodeTxStatus) GetValidationCode() peer.TxValidationCode {
+	if m != nil {
+		return m.ValidationCode
+	}
+	return peer.TxValidationCode_VALID
+}
+
 func init() {
 	proto.RegisterType((*ChaincodeInput)(nil), "service.ChaincodeInput")
 	proto.RegisterMapType((map[string][]byte)(nil), "service.ChaincodeInput.TransientEntry")
 	proto.RegisterType((*ChaincodeLocator)(nil), "service.ChaincodeLocator")
+	proto.RegisterType((*ChaincodeEventsStreamRequest)(nil), "service.ChaincodeEventsStreamRequest")
+	proto.RegisterType((*ChaincodeEventCheckpoint)(nil), "service.ChaincodeEventCheckpoint")
+	proto.RegisterType((*ChaincodeEvent)(nil), "service.ChaincodeEvent")
+	proto.RegisterType((*ChaincodeTxLocator)(nil), "service.ChaincodeTxLocator")
+	proto.RegisterType((*ChaincodeSubmitResponse)(nil), "service.ChaincodeSubmitResponse")
+	proto.RegisterType((*ChaincodeTxStatus)(nil), "service.ChaincodeTxStatus")
 }
 
 func init() { proto.RegisterFile("chaincode.proto", fileDescriptor_97136ef4b384cc22) }
 
 var fileDescriptor_97136ef4b384cc22 = []byte{
-	// 335 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x51, 0x4f, 0x4b, 0xfb, 0x40,
-	0x10, 0x25, 0x4d, 0xff, 0x90, 0xf9, 0x95, 0xfe, 0xca, 0x22, 0x1a, 0x83, 0x87, 0xd2, 0x83, 0xf4,
-	0x94, 0x48, 0xbd, 0x48, 0x55, 0x44, 0xb4, 0x87, 0x8a, 0x07, 0x0d, 0xde, 0xcb, 0x76, 0x3b, 0x36,
-	0xa1, 0x71, 0x37, 0xec, 0x6e, 0x02, 0xf9, 0x7c, 0x7e, 0x11, 0x3f, 0x8a, 0x34, 0xdd, 0xa4, 0x14,
-	0x11, 0xa4, 0xb7, 0x99, 0x7d, 0xf3, 0xde, 0xcc, 0xdb, 0x07, 0xff, 0x59, 0x44, 0x63, 0xce, 0xc4,
-	0x12, 0xfd, 0x54, 0x0a, 0x2d, 0x48, 0x47, 0xa1, 0xcc, 0x63, 0x86, 0xde, 0xfd, 0x2a, 0xd6, 0x51,
-	0xb6, 0xf0, 0x99, 0xf8, 0x08, 0xa2, 0x22, 0x45, 0x99, 0xe0, 0x72, 0x85, 0x32, 0x78, 0xa7, 0x0b,
-	0x19, 0xb3, 0xa0, 0x9c, 0x56, 0x41, 0x8a, 0x28, 0x37, 0x75, 0x2a, 0x14, 0x4d, 0xe6, 0x12, 0x55,
-	0x2a, 0xb8, 0x32, 0x5a, 0xde, 0xdd, 0xdf, 0x25, 0xea, 0x33, 0xe6, 0x98, 0x23, 0xd7, 0x5b, 0x81,
-	0xe1, 0x97, 0x05, 0xbd, 0x87, 0x0a, 0x99, 0xf1, 0x34, 0xd3, 0xe4, 0x0c, 0x9c, 0x7a, 0xd6, 0xb5,
-	0x06, 0xd6, 0xc8, 0x09, 0x77, 0x0f, 0xc4, 0x85, 0x0e, 0x8b, 0x28, 0xe7, 0x98, 0xb8, 0x8d, 0x12,
-	0xab, 0x5a, 0x42, 0xa0, 0x49, 0xe5, 0x4a, 0xb9, 0xf6, 0xc0, 0x1e, 0x75, 0xc3, 0xb2, 0x26, 0x8f,
-	0xe0, 0x68, 0x49, 0xb9, 0x8a, 0x91, 0x6b, 0xb7, 0x39, 0xb0, 0x47, 0xff, 0xc6, 0xe7, 0xbe, 0xf1,
-	0xef, 0xef, 0xef, 0xf5, 0xdf, 0xaa, 0xc1, 0x29, 0xd7, 0xb2, 0x08, 0x77, 0x44, 0xef, 0x06, 0x7a,
-	0xfb, 0x20, 0xe9, 0x83, 0xbd, 0xc6, 0xc2, 0x5c, 0xb7, 0x29, 0xc9, 0x11, 0xb4, 0x72, 0x9a, 0x64,
-	0x58, 0x5e, 0xd5, 0x0d, 0xb7, 0xcd, 0xa4, 0x71, 0x65, 0x0d, 0x9f, 0xa0, 0x5f, 0x6f, 0x7a, 0x16,
-	0x8c, 0x6a, 0x21, 0x0f, 0xf5, 0x38, 0xfe, 0xb4, 0xc0, 0xa9, 0xc5, 0xc8, 0x04, 0x5a, 0xaf, 0x19,
-	0xca, 0x82, 0x9c, 0xfc, 0xe2, 0xc9, 0x73, 0xb7, 0xdf, 0xac, 0xfc, 0x17, 0x13, 0x60, 0x68, 0xf2,
-	0x23, 0xd7, 0xd0, 0x9e, 0xf1, 0x5c, 0xac, 0xf1, 0x10, 0xf2, 0x2d, 0xb4, 0xa7, 0x9b, 0x10, 0x15,
-	0x39, 0xfd, 0x49, 0x36, 0x1e, 0xbd, 0xe3, 0x8a, 0x5e, 0x23, 0x25, 0xe7, 0xc2, 0x5a, 0xb4, 0x4b,
-	0xe0, 0xf2, 0x3b, 0x00, 0x00, 0xff, 0xff, 0x63, 0x4d, 0xd9, 0x47, 0x9b, 0x02, 0x00, 0x00,
+	// 673 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0x5d, 0x6f, 0xd3, 0x3c,
+	0x14, 0x56, 0xfa, 0xb9, 0x9e, 0x4e, 0xdd, 0x5e, 0xbf, 0xaf, 0xde, 0x65, 0x61, 0x88, 0x50, 0x09,
+	0xd4, 0x8b, 0xa9, 0x9d, 0x0a, 0x17, 0x68, 0x03, 0xa1, 0x51, 0x76, 0x51, 0x40, 0x68, 0x78, 0x13,
+	0xb7, 0x91, 0x9b, 0x78, 0x6d, 0xd4, 0xd6, 0x0e, 0x8e, 0x53, 0xa5, 0x97, 0xfc, 0x12, 0x24, 0x7e,
+	0x19, 0x3f, 0x05, 0xc5, 0x49, 0xdc, 0x76, 0x5d, 0xa4, 0xa9, 0x77, 0xf6, 0x39, 0xcf, 0xf9, 0x78,
+	0xfc, 0x1c, 0x1f, 0x38, 0x70, 0x27, 0xc4, 0x67, 0x2e, 0xf7, 0x68, 0x37, 0x10, 0x5c, 0x72, 0x54,
+	0x0f, 0xa9, 0x58, 0xf8, 0x2e, 0xb5, 0x2e, 0xc7, 0xbe, 0x9c, 0x44, 0xa3, 0xae, 0xcb, 0xe7, 0xbd,
+	0xc9, 0x32, 0xa0, 0x62, 0x46, 0xbd, 0x31, 0x15, 0xbd, 0x3b, 0x32, 0x12, 0xbe, 0xdb, 0x53, 0xe8,
+	0xb0, 0x17, 0x50, 0x2a, 0x92, 0x73, 0xc0, 0x43, 0x32, 0x73, 0x04, 0x0d, 0x03, 0xce, 0xc2, 0x2c,
+	0x97, 0xf5, 0xfe, 0xf1, 0x29, 0x74, 0x1b, 0x0e, 0x5d, 0x50, 0x26, 0xb3, 0x04, 0x17, 0x8f, 0x4f,
+	0x20, 0x05, 0x61, 0x21, 0x71, 0xa5, 0xcf, 0x59, 0x1a, 0xdc, 0xfe, 0x63, 0x40, 0x6b, 0x90, 0xa7,
+	0x1d, 0xb2, 0x20, 0x92, 0xe8, 0x04, 0x1a, 0xba, 0x90, 0x69, 0xd8, 0x46, 0xa7, 0x81, 0x57, 0x06,
+	0x64, 0x42, 0xdd, 0x9d, 0x10, 0xc6, 0xe8, 0xcc, 0x2c, 0x29, 0x5f, 0x7e, 0x45, 0x08, 0x2a, 0x44,
+	0x8c, 0x43, 0xb3, 0x6c, 0x97, 0x3b, 0xfb, 0x58, 0x9d, 0xd1, 0x47, 0x68, 0xa8, 0x9a, 0x3e, 0x65,
+	0xd2, 0xac, 0xd8, 0xe5, 0x4e, 0xb3, 0xff, 0xb2, 0x9b, 0x3d, 0x5e, 0x77, 0xb3, 0x6e, 0xf7, 0x36,
+	0x07, 0x5e, 0x31, 0x29, 0x96, 0x78, 0x15, 0x68, 0xbd, 0x85, 0xd6, 0xa6, 0x13, 0x1d, 0x42, 0x79,
+	0x4a, 0x97, 0x59, 0x77, 0xc9, 0x11, 0xfd, 0x07, 0xd5, 0x05, 0x99, 0x45, 0x54, 0x75, 0xb5, 0x8f,
+	0xd3, 0xcb, 0x79, 0xe9, 0x8d, 0xd1, 0xfe, 0x04, 0x87, 0xba, 0xd2, 0x17, 0xee, 0x12, 0xc9, 0xc5,
+	0xae, 0x1c, 0xdb, 0xbf, 0x4b, 0x70, 0xa2, 0x93, 0x5d, 0x25, 0x22, 0x84, 0x37, 0x52, 0x50, 0x32,
+	0xc7, 0xf4, 0x47, 0x44, 0xc3, 0xdd, 0x1f, 0xef, 0x19, 0x34, 0x95, 0xa6, 0x0e, 0x23, 0x73, 0x9a,
+	0xbe, 0x61, 0x03, 0x83, 0x32, 0x7d, 0x4d, 0x2c, 0xe8, 0x14, 0xd0, 0x0a, 0xe0, 0x08, 0x3a, 0xa6,
+	0x71, 0x10, 0xaa, 0x27, 0x6d, 0xe0, 0x43, 0x8d, 0xc3, 0xa9, 0x1d, 0x3d, 0x05, 0xb8, 0x13, 0x7c,
+	0xee, 0x8c, 0x66, 0xdc, 0x9d, 0x9a, 0x55, 0xdb, 0xe8, 0x54, 0x70, 0x23, 0xb1, 0x7c, 0x48, 0x0c,
+	0xe8, 0x18, 0xf6, 0x24, 0xcf, 0x9c, 0x35, 0xe5, 0xac, 0x4b, 0x9e, 0xba, 0x2e, 0x01, 0xdc, 0x09,
+	0x75, 0xa7, 0x01, 0xf7, 0x99, 0x34, 0xeb, 0xb6, 0xd1, 0x69, 0xf6, 0x9f, 0x6f, 0x4b, 0xa6, 0xb8,
+	0x0f, 0x34, 0x10, 0xaf, 0x05, 0xb5, 0x3f, 0x83, 0x59, 0x84, 0x4b, 0x64, 0x4a, 0xcb, 0x1a, 0xaa,
+	0x6c, 0x75, 0xa4, 0xfb, 0x89, 0x1d, 0x9f, 0x79, 0x34, 0x36, 0x4b, 0x59, 0x3f, 0xf1, 0x30, 0xb9,
+	0xb6, 0x7f, 0xae, 0x0f, 0xa8, 0xca, 0x86, 0x4e, 0xa1, 0xaa, 0x08, 0xab, 0x1c, 0xcd, 0xfe, 0xff,
+	0xe9, 0x28, 0x87, 0xf7, 0x9a, 0xc3, 0x29, 0xe8, 0x1e, 0xa1, 0xd2, 0x2e, 0x84, 0x06, 0x80, 0x34,
+	0xee, 0x36, 0xce, 0x67, 0x68, 0x4d, 0x4c, 0x63, 0x53, 0xcc, 0x7f, 0xa1, 0x9a, 0xd0, 0xf1, 0x32,
+	0x91, 0x2b, 0x32, 0x1e, 0x7a, 0x6d, 0x0f, 0x8e, 0x74, 0x92, 0x9b, 0x68, 0x34, 0xf7, 0x25, 0xce,
+	0x16, 0xc1, 0x0a, 0x6f, 0xac, 0xf0, 0xe8, 0x35, 0xec, 0xe5, 0x9b, 0x22, 0xeb, 0xda, 0xcc, 0x89,
+	0x5e, 0x67, 0xab, 0x24, 0x4f, 0x80, 0x35, 0x32, 0x79, 0xae, 0x7f, 0xd6, 0x7a, 0xbd, 0x91, 0x44,
+	0x46, 0xe1, 0xc3, 0x05, 0xb4, 0x14, 0xa5, 0x75, 0x29, 0x2e, 0xe1, 0x60, 0x41, 0x66, 0xbe, 0x47,
+	0x92, 0x25, 0xe1, 0xa8, 0x31, 0x2e, 0xdb, 0x46, 0xa7, 0xb5, 0xaa, 0x7e, 0x1b, 0x7f, 0xd7, 0x80,
+	0x01, 0xf7, 0x28, 0x6e, 0x2d, 0x36, 0xee, 0xfd, 0x5f, 0x65, 0x68, 0xe8, 0x1e, 0xd0, 0x39, 0x54,
+	0xbf, 0x45, 0x54, 0x2c, 0xd1, 0x51, 0xc1, 0xc7, 0xb7, 0x0a, 0x79, 0xa1, 0x0b, 0xa8, 0x0d, 0xd9,
+	0x82, 0x4f, 0xe9, 0x2e, 0xc1, 0xef, 0xa0, 0x96, 0xfe, 0x50, 0x74, 0xbc, 0x1d, 0x9c, 0x89, 0x68,
+	0x15, 0x0c, 0xcf, 0x99, 0x81, 0xae, 0x61, 0x7f, 0xfd, 0x83, 0xa3, 0x17, 0x05, 0x33, 0xb3, 0xb9,
+	0x00, 0xac, 0xa3, 0x02, 0xd8, 0x99, 0x81, 0x06, 0x50, 0x4b, 0x85, 0x2f, 0x66, 0x63, 0x6f, 0x3b,
+	0xee, 0xcd, 0xca, 0x00, 0xf6, 0xb4, 0xac, 0x4f, 0xb6, 0xd1, 0x7a, 0x3c, 0x2d, 0xeb, 0x21, 0x67,
+	0x1a, 0x38, 0xaa, 0x29, 0xd2, 0xaf, 0xfe, 0x0e, 0x00, 0xe2, 0x7a, 0x50, 0xdd, 0xd9, 0x06, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -193,6 +572,12 @@ type ChaincodeClient interface {
 	Invoke(ctx context.Context, in *ChaincodeInput, opts ...grpc.CallOption) (*peer.ProposalResponse, error)
 	// Chaincode events stream
 	Events(ctx context.Context, in *ChaincodeLocator, opts ...grpc.CallOption) (Chaincode_EventsClient, error)
+	// Chaincode events stream with event name filters, blocks range and checkpoints
+	EventsStream(ctx context.Context, in *ChaincodeEventsStreamRequest, opts ...grpc.CallOption) (Chaincode_EventsStreamClient, error)
+	// Submit chaincode invoke to orderer, doesn't wait for transaction commit
+	Submit(ctx context.Context, in *ChaincodeInput, opts ...grpc.CallOption) (*ChaincodeSubmitResponse, error)
+	// Wait for transaction commit and return its status
+	TxStatus(ctx context.Context, in *ChaincodeTxLocator, opts ...grpc.CallOption) (*ChaincodeTxStatus, error)
 }
 
 type chaincodeClient struct {
@@ -253,6 +638,56 @@ func (x *chaincodeEventsClient) Recv() (*peer.ChaincodeEvent, error) {
 	return m, nil
 }
 
+func (c *chaincodeClient) EventsStream(ctx context.Context, in *ChaincodeEventsStreamRequest, opts ...grpc.CallOption) (Chaincode_EventsStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Chaincode_serviceDesc.Streams[1], "/service.Chaincode/EventsStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &chaincodeEventsStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Chaincode_EventsStreamClient interface {
+	Recv() (*ChaincodeEvent, error)
+	grpc.ClientStream
+}
+
+type chaincodeEventsStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *chaincodeEventsStreamClient) Recv() (*ChaincodeEvent, error) {
+	m := new(ChaincodeEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *chaincodeClient) Submit(ctx context.Context, in *ChaincodeInput, opts ...grpc.CallOption) (*ChaincodeSubmitResponse, error) {
+	out := new(ChaincodeSubmitResponse)
+	err := c.cc.Invoke(ctx, "/service.Chaincode/Submit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chaincodeClient) TxStatus(ctx context.Context, in *ChaincodeTxLocator, opts ...grpc.CallOption) (*ChaincodeTxStatus, error) {
+	out := new(ChaincodeTxStatus)
+	err := c.cc.Invoke(ctx, "/service.Chaincode/TxStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChaincodeServer is the server API for Chaincode service.
 type ChaincodeServer interface {
 	// Query chaincode on home peer. Do NOT send to orderer.
@@ -261,6 +696,12 @@ type ChaincodeServer interface {
 	Invoke(context.Context, *ChaincodeInput) (*peer.ProposalResponse, error)
 	// Chaincode events stream
 	Events(*ChaincodeLocator, Chaincode_EventsServer) error
+	// Chaincode events stream with event name filters, blocks range and checkpoints
+	EventsStream(*ChaincodeEventsStreamRequest, Chaincode_EventsStreamServer) error
+	// Submit chaincode invoke to orderer, doesn't wait for transaction commit
+	Submit(context.Context, *ChaincodeInput) (*ChaincodeSubmitResponse, error)
+	// Wait for transaction commit and return its status
+	TxStatus(context.Context, *ChaincodeTxLocator) (*ChaincodeTxStatus, error)
 }
 
 func RegisterChaincodeServer(s *grpc.Server, srv ChaincodeServer) {
@@ -324,6 +765,63 @@ func (x *chaincodeEventsServer) Send(m *peer.ChaincodeEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Chaincode_EventsStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ChaincodeEventsStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChaincodeServer).EventsStream(m, &chaincodeEventsStreamServer{stream})
+}
+
+type Chaincode_EventsStreamServer interface {
+	Send(*ChaincodeEvent) error
+	grpc.ServerStream
+}
+
+type chaincodeEventsStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *chaincodeEventsStreamServer) Send(m *ChaincodeEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Chaincode_Submit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChaincodeInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaincodeServer).Submit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.Chaincode/Submit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaincodeServer).Submit(ctx, req.(*ChaincodeInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chaincode_TxStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChaincodeTxLocator)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaincodeServer).TxStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.Chaincode/TxStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaincodeServer).TxStatus(ctx, req.(*ChaincodeTxLocator))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Chaincode_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "service.Chaincode",
 	HandlerType: (*ChaincodeServer)(nil),
@@ -336,6 +834,14 @@ var _Chaincode_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Invoke",
 			Handler:    _Chaincode_Invoke_Handler,
 		},
+		{
+			MethodName: "Submit",
+			Handler:    _Chaincode_Submit_Handler,
+		},
+		{
+			MethodName: "TxStatus",
+			Handler:    _Chaincode_TxStatus_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -343,6 +849,11 @@ var _Chaincode_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Chaincode_Events_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "EventsStream",
+			Handler:       _Chaincode_EventsStream_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "chaincode.proto",
 }
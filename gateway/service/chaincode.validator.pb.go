@@ -10,14 +10,22 @@ It is generated from these files:
 It has these top-level messages:
 	ChaincodeInput
 	ChaincodeLocator
+	ChaincodeEventsStreamRequest
+	ChaincodeEventCheckpoint
+	ChaincodeEvent
+	ChaincodeTxLocator
+	ChaincodeSubmitResponse
+	ChaincodeTxStatus
 */
 package service
 
-import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
+import go_proto_validators "github.com/mwitkow/go-proto-validators"
+import proto "github.com/golang/protobuf/proto"
 import math "math"
 import _ "github.com/hyperledger/fabric/protos/peer"
 import _ "github.com/hyperledger/fabric/protos/peer"
+import _ "github.com/hyperledger/fabric/protos/peer"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -31,3 +39,41 @@ func (this *ChaincodeInput) Validate() error {
 func (this *ChaincodeLocator) Validate() error {
 	return nil
 }
+func (this *ChaincodeEventsStreamRequest) Validate() error {
+	if this.Checkpoint != nil {
+		if err := go_proto_validators.CallValidatorIfExists(this.Checkpoint); err != nil {
+			return go_proto_validators.FieldError("Checkpoint", err)
+		}
+	}
+	return nil
+}
+func (this *ChaincodeEventCheckpoint) Validate() error {
+	return nil
+}
+func (this *ChaincodeEvent) Validate() error {
+	if this.Event != nil {
+		if err := go_proto_validators.CallValidatorIfExists(this.Event); err != nil {
+			return go_proto_validators.FieldError("Event", err)
+		}
+	}
+	if this.Checkpoint != nil {
+		if err := go_proto_validators.CallValidatorIfExists(this.Checkpoint); err != nil {
+			return go_proto_validators.FieldError("Checkpoint", err)
+		}
+	}
+	return nil
+}
+func (this *ChaincodeTxLocator) Validate() error {
+	return nil
+}
+func (this *ChaincodeSubmitResponse) Validate() error {
+	if this.Response != nil {
+		if err := go_proto_validators.CallValidatorIfExists(this.Response); err != nil {
+			return go_proto_validators.FieldError("Response", err)
+		}
+	}
+	return nil
+}
+func (this *ChaincodeTxStatus) Validate() error {
+	return nil
+}
@@ -7,4 +7,16 @@ var (
 	ErrChaincodeNotExists = errors.New(`chaincode not exists`)
 
 	ErrSignerNotDefinedInContext = errors.New(`signer is not defined in context`)
+
+	// ErrEventNameRegexpInvalid occurs when events stream request contains invalid event name regexp
+	ErrEventNameRegexpInvalid = errors.New(`event name regexp invalid`)
+
+	// ErrEventsStreamRangeInvalid occurs when events stream last block is before first block
+	ErrEventsStreamRangeInvalid = errors.New(`events stream range invalid`)
+
+	// ErrTxNotFound occurs when transaction is not found in ledger
+	ErrTxNotFound = errors.New(`transaction not found`)
+
+	// ErrSubmitterNotDefined occurs when Submit is called on service, created without WithSubmitter option
+	ErrSubmitterNotDefined = errors.New(`submitter not defined`)
 )
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+type (
+	// EventsStreamFilter selects chaincode events by name, block range and checkpoint
+	EventsStreamFilter struct {
+		names      map[string]bool
+		regexps    []*regexp.Regexp
+		fromBlock  uint64
+		toBlock    uint64
+		checkpoint *ChaincodeEventCheckpoint
+	}
+)
+
+// NewEventsStreamFilter creates filter from events stream request, event name regexps are compiled
+func NewEventsStreamFilter(in *ChaincodeEventsStreamRequest) (*EventsStreamFilter, error) {
+	f := &EventsStreamFilter{
+		names:      make(map[string]bool),
+		fromBlock:  in.FromBlock,
+		toBlock:    in.ToBlock,
+		checkpoint: in.Checkpoint,
+	}
+
+	for _, name := range in.EventNames {
+		f.names[name] = true
+	}
+	for _, expr := range in.EventNameRegexps {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf(`%s: %s`, ErrEventNameRegexpInvalid, err)
+		}
+		f.regexps = append(f.regexps, re)
+	}
+
+	if f.toBlock > 0 && f.toBlock < f.StartBlock() {
+		return nil, fmt.Errorf(`%s: to block %d before start block %d`, ErrEventsStreamRangeInvalid, f.toBlock, f.StartBlock())
+	}
+	return f, nil
+}
+
+// StartBlock returns first block of stream, 0 - stream starts from new blocks
+func (f *EventsStreamFilter) StartBlock() uint64 {
+	if f.checkpoint != nil {
+		return f.checkpoint.Block
+	}
+	return f.fromBlock
+}
+
+// ToBlock returns last block of stream, 0 - stream is not limited
+func (f *EventsStreamFilter) ToBlock() uint64 {
+	return f.toBlock
+}
+
+// Done returns true if stream block range ends before block
+func (f *EventsStreamFilter) Done(block uint64) bool {
+	return f.toBlock > 0 && block > f.toBlock
+}
+
+// Match returns true if event is in stream range, after checkpoint and its name matches filters
+func (f *EventsStreamFilter) Match(e *ChaincodeEvent) bool {
+	if e.Checkpoint != nil {
+		if f.Done(e.Checkpoint.Block) || !f.afterStart(e.Checkpoint) {
+			return false
+		}
+	}
+	return f.MatchName(e.Event.EventName)
+}
+
+// MatchName returns true if name equals one of filter names or matches one of regexps, or filter has no names
+func (f *EventsStreamFilter) MatchName(name string) bool {
+	if len(f.names) == 0 && len(f.regexps) == 0 {
+		return true
+	}
+	if f.names[name] {
+		return true
+	}
+	for _, re := range f.regexps {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *EventsStreamFilter) afterStart(cp *ChaincodeEventCheckpoint) bool {
+	if f.checkpoint != nil {
+		return CheckpointAfter(cp, f.checkpoint)
+	}
+	return cp.Block >= f.fromBlock
+}
+
+// CheckpointAfter returns true if checkpoint a is after checkpoint b
+func CheckpointAfter(a, b *ChaincodeEventCheckpoint) bool {
+	if a.Block != b.Block {
+		return a.Block > b.Block
+	}
+	return a.TxIndex > b.TxIndex
+}
+
+// BlockChaincodeEvents returns events of chaincode from valid transactions of block
+func BlockChaincodeEvents(block *common.Block, chaincode string) ([]*ChaincodeEvent, error) {
+	var (
+		events []*ChaincodeEvent
+		filter []byte
+	)
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		filter = block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER]
+	}
+
+	for i, data := range block.Data.Data {
+		if i < len(filter) && peer.TxValidationCode(filter[i]) != peer.TxValidationCode_VALID {
+			continue
+		}
+
+		event, err := txChaincodeEvent(data)
+		if err != nil {
+			return nil, fmt.Errorf(`block %d tx %d: %s`, block.Header.Number, i, err)
+		}
+		if event == nil || event.ChaincodeId != chaincode {
+			continue
+		}
+
+		events = append(events, &ChaincodeEvent{
+			Event:      event,
+			Checkpoint: &ChaincodeEventCheckpoint{Block: block.Header.Number, TxIndex: uint64(i)},
+		})
+	}
+	return events, nil
+}
+
+// txChaincodeEvent returns chaincode event of endorser transaction, nil if tx has no event
+func txChaincodeEvent(data []byte) (*peer.ChaincodeEvent, error) {
+	env, err := utils.GetEnvelopeFromBlock(data)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := utils.GetPayload(env)
+	if err != nil {
+		return nil, err
+	}
+	chHeader, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+	if common.HeaderType(chHeader.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+		return nil, nil
+	}
+
+	action, err := utils.GetActionFromEnvelopeMsg(env)
+	if err != nil {
+		return nil, err
+	}
+	if len(action.Events) == 0 {
+		return nil, nil
+	}
+	event, err := utils.GetChaincodeEvents(action.Events)
+	if err != nil || event.EventName == `` {
+		return nil, err
+	}
+	return event, nil
+}
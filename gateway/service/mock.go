@@ -2,13 +2,15 @@ package service
 
 import (
 	"context"
-	"errors"
+	"crypto/rand"
 	"fmt"
 	"sync"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/protos/peer"
+	ccerrors "github.com/optherium/cckit/errors"
 	"github.com/optherium/cckit/testing"
 )
 
@@ -25,12 +27,25 @@ type (
 		// channel name -> chaincode name
 		ChannelCC ChannelsMockStubs
 		m         sync.Mutex
+
+		// channel name -> chaincode events, recorded from invokes via service, each invoke is a block
+		eventLog map[string][]*ChaincodeEvent
+		// channel name -> number of last block
+		blocks map[string]uint64
+		// channel name -> tx id -> status of tx, invoked via service
+		txs map[string]map[string]*ChaincodeTxStatus
+		// closed and replaced on every new block
+		newBlock chan struct{}
 	}
 )
 
 func NewMock() *MockChaincodeService {
 	return &MockChaincodeService{
 		ChannelCC: make(ChannelsMockStubs),
+		eventLog:  make(map[string][]*ChaincodeEvent),
+		blocks:    make(map[string]uint64),
+		txs:       make(map[string]map[string]*ChaincodeTxStatus),
+		newBlock:  make(chan struct{}),
 	}
 }
 func (cs *MockChaincodeService) Query(ctx context.Context, in *ChaincodeInput) (proposalResponse *peer.ProposalResponse, err error) {
@@ -51,8 +66,8 @@ func (cs *MockChaincodeService) Query(ctx context.Context, in *ChaincodeInput) (
 		return
 	}
 
-	if response = mockStub.From(signer).WithTransient(in.Transient).QueryBytes(in.Args...); response.Status == shim.ERROR {
-		return nil, errors.New(response.Message)
+	if response = mockStub.From(signer).WithTransient(in.Transient).QueryBytes(in.Args...); response.Status >= shim.ERRORTHRESHOLD {
+		return nil, ccerrors.FromResponse(response)
 	}
 
 	return &peer.ProposalResponse{
@@ -62,7 +77,40 @@ func (cs *MockChaincodeService) Query(ctx context.Context, in *ChaincodeInput) (
 	}, nil
 }
 
-func (cs *MockChaincodeService) Invoke(ctx context.Context, in *ChaincodeInput) (proposalResponse *peer.ProposalResponse, err error) {
+func (cs *MockChaincodeService) Invoke(ctx context.Context, in *ChaincodeInput) (*peer.ProposalResponse, error) {
+	_, proposalResponse, err := cs.invoke(ctx, in)
+	return proposalResponse, err
+}
+
+// Submit invokes chaincode, mock commits transaction immediately
+func (cs *MockChaincodeService) Submit(ctx context.Context, in *ChaincodeInput) (*ChaincodeSubmitResponse, error) {
+	txId, proposalResponse, err := cs.invoke(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return &ChaincodeSubmitResponse{TxId: txId, Response: proposalResponse}, nil
+}
+
+// TxStatus returns status of transaction, invoked via service, waits if transaction is not committed yet
+func (cs *MockChaincodeService) TxStatus(ctx context.Context, in *ChaincodeTxLocator) (*ChaincodeTxStatus, error) {
+	for {
+		cs.m.Lock()
+		status, newBlock := cs.txs[in.Channel][in.TxId], cs.newBlock
+		cs.m.Unlock()
+
+		if status != nil {
+			return proto.Clone(status).(*ChaincodeTxStatus), nil
+		}
+
+		select {
+		case <-newBlock:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (cs *MockChaincodeService) invoke(ctx context.Context, in *ChaincodeInput) (txId string, proposalResponse *peer.ProposalResponse, err error) {
 	var (
 		mockStub *testing.MockStub
 		signer   msp.SigningIdentity
@@ -80,16 +128,99 @@ func (cs *MockChaincodeService) Invoke(ctx context.Context, in *ChaincodeInput)
 		return
 	}
 
-	if response = mockStub.From(signer).WithTransient(in.Transient).InvokeBytes(in.Args...); response.Status == shim.ERROR {
-		return nil, errors.New(response.Message)
+	if txId, err = newTxId(); err != nil {
+		return
+	}
+
+	if response = mockStub.From(signer).WithTransient(in.Transient).MockInvoke(txId, in.Args); response.Status >= shim.ERRORTHRESHOLD {
+		return ``, nil, ccerrors.FromResponse(response)
 	}
 
-	return &peer.ProposalResponse{
+	cs.commitBlock(in.Channel, in.Chaincode, txId, mockStub.ChaincodeEvent)
+
+	return txId, &peer.ProposalResponse{
 		Version:   MessageProtocolVersion,
 		Timestamp: mockStub.TxTimestamp,
 		Response:  &response,
 	}, nil
+}
+
+func newTxId() (string, error) {
+	id := make([]byte, 32)
+	if _, err := rand.Read(id); err != nil {
+		return ``, err
+	}
+	return fmt.Sprintf(`0x%x`, id), nil
+}
 
+// commitBlock adds block with invoke tx to channel and records tx status and event, must be called under lock
+func (cs *MockChaincodeService) commitBlock(channel, chaincode, txId string, event *peer.ChaincodeEvent) {
+	cs.blocks[channel]++
+	if _, ok := cs.txs[channel]; !ok {
+		cs.txs[channel] = make(map[string]*ChaincodeTxStatus)
+	}
+	cs.txs[channel][txId] = &ChaincodeTxStatus{
+		TxId:           txId,
+		Block:          cs.blocks[channel],
+		ValidationCode: peer.TxValidationCode_VALID,
+	}
+	if event != nil {
+		e := proto.Clone(event).(*peer.ChaincodeEvent)
+		e.ChaincodeId = chaincode
+		e.TxId = txId
+		cs.eventLog[channel] = append(cs.eventLog[channel], &ChaincodeEvent{
+			Event:      e,
+			Checkpoint: &ChaincodeEventCheckpoint{Block: cs.blocks[channel]},
+		})
+	}
+
+	close(cs.newBlock)
+	cs.newBlock = make(chan struct{})
+}
+
+// EventsStream replays recorded chaincode events from requested block or checkpoint, then streams new events
+func (cs *MockChaincodeService) EventsStream(in *ChaincodeEventsStreamRequest, stream Chaincode_EventsStreamServer) error {
+	filter, err := NewEventsStreamFilter(in)
+	if err != nil {
+		return err
+	}
+
+	cs.m.Lock()
+	_, err = cs.Chaincode(in.Channel, in.Chaincode)
+	start := filter.StartBlock()
+	if start == 0 {
+		start = cs.blocks[in.Channel] + 1
+	}
+	cs.m.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for pos := 0; ; {
+		cs.m.Lock()
+		events, height, newBlock := cs.eventLog[in.Channel], cs.blocks[in.Channel], cs.newBlock
+		cs.m.Unlock()
+
+		for ; pos < len(events); pos++ {
+			e := events[pos]
+			if e.Checkpoint.Block < start || e.Event.ChaincodeId != in.Chaincode || !filter.Match(e) {
+				continue
+			}
+			if err = stream.Send(e); err != nil {
+				return err
+			}
+		}
+
+		if filter.ToBlock() > 0 && height >= filter.ToBlock() {
+			return nil
+		}
+
+		select {
+		case <-newBlock:
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
 }
 
 func (cs *MockChaincodeService) Events(in *ChaincodeLocator, stream Chaincode_EventsServer) (err error) {
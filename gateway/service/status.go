@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric/protos/peer"
+	ccerrors "github.com/optherium/cckit/errors"
+	"github.com/pkg/errors"
+	"github.com/s7techlab/hlf-sdk-go/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ToStatus converts error with CCError in chain to gRPC status error, carrying CCError as peer.Response in details,
+// other errors are returned as is
+func ToStatus(err error) error {
+	ccErr, ok := ccerrors.AsCCError(err)
+	if !ok {
+		return err
+	}
+	if _, isStatus := status.FromError(err); isStatus {
+		return err
+	}
+
+	// chaincode error codes are not related to gRPC codes, code is restored from details
+	response := ccErr.Response()
+	st, stErr := status.New(codes.Unknown, ccErr.Error()).WithDetails(&response)
+	if stErr != nil {
+		return status.Error(codes.Unknown, ccErr.Error())
+	}
+	return st.Err()
+}
+
+// FromStatus decodes CCError from gRPC status details, returns err as is if status doesn't carry CCError
+func FromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || st == nil {
+		return err
+	}
+	for _, detail := range st.Details() {
+		if response, isResponse := detail.(*peer.Response); isResponse {
+			if ccErr := ccerrors.FromResponse(*response); ccErr != nil {
+				return ccErr
+			}
+		}
+	}
+	return err
+}
+
+// UnaryServerErrorInterceptor converts CCError, returned by service method, to gRPC status with CCError in details
+func UnaryServerErrorInterceptor(
+	ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	res, err := handler(ctx, req)
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	return res, nil
+}
+
+// UnaryClientErrorInterceptor decodes CCError from gRPC status details, returned by server
+func UnaryClientErrorInterceptor(
+	ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return FromStatus(invoker(ctx, method, req, reply, cc, opts...))
+}
+
+// endorseError converts peer endorsement error, returned by hlf-sdk-go, to CCError with chaincode response status
+// and message. hlf-sdk-go doesn't pass error response payload, so reason and details of CCError are not restored
+func endorseError(err error) error {
+	errs := []error{errors.Cause(err)}
+	if mErr, ok := errs[0].(*api.MultiError); ok {
+		errs = mErr.Errors
+	}
+
+	for _, e := range errs {
+		if endorseErr, ok := errors.Cause(e).(api.PeerEndorseError); ok {
+			return ccerrors.FromResponse(peer.Response{Status: endorseErr.Status, Message: endorseErr.Message})
+		}
+	}
+	return err
+}
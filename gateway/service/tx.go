@@ -0,0 +1,50 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// BlockTxStatus returns status of transaction in block, nil if block doesn't contain transaction
+func BlockTxStatus(block *common.Block, txId string) (*ChaincodeTxStatus, error) {
+	var filter []byte
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		filter = block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER]
+	}
+
+	for i, data := range block.Data.Data {
+		id, err := envelopeTxId(data)
+		if err != nil {
+			return nil, fmt.Errorf(`block %d tx %d: %s`, block.Header.Number, i, err)
+		}
+		if id != txId {
+			continue
+		}
+
+		status := &ChaincodeTxStatus{TxId: id, Block: block.Header.Number, ValidationCode: peer.TxValidationCode_VALID}
+		if i < len(filter) {
+			status.ValidationCode = peer.TxValidationCode(filter[i])
+		}
+		return status, nil
+	}
+	return nil, nil
+}
+
+func envelopeTxId(data []byte) (string, error) {
+	env, err := utils.GetEnvelopeFromBlock(data)
+	if err != nil {
+		return ``, err
+	}
+	payload, err := utils.GetPayload(env)
+	if err != nil {
+		return ``, err
+	}
+	chHeader, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return ``, err
+	}
+	return chHeader.TxId, nil
+}
@@ -0,0 +1,129 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric/msp"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	AuthorizationHeader = `authorization`
+	BearerPrefix        = `Bearer `
+)
+
+type (
+	// SignerResolver resolves signing identity of caller per request
+	SignerResolver interface {
+		Resolve(ctx context.Context) (msp.SigningIdentity, error)
+	}
+
+	SignerResolverFunc func(ctx context.Context) (msp.SigningIdentity, error)
+
+	// CallerIdFunc extracts caller id (API key, JWT subject, certificate common name) from request context,
+	// returns ErrCallerIdNotFound if request has no credential of this kind
+	CallerIdFunc func(ctx context.Context) (string, error)
+
+	// IdentityStore returns signing identity by caller id
+	IdentityStore interface {
+		Identity(callerId string) (msp.SigningIdentity, error)
+	}
+
+	// IdentityMap caller id -> signing identity
+	IdentityMap map[string]msp.SigningIdentity
+
+	// JWTVerifier verifies token and returns its subject
+	JWTVerifier func(token string) (subject string, err error)
+)
+
+func (f SignerResolverFunc) Resolve(ctx context.Context) (msp.SigningIdentity, error) {
+	return f(ctx)
+}
+
+func (m IdentityMap) Identity(callerId string) (msp.SigningIdentity, error) {
+	if signer, ok := m[callerId]; ok {
+		return signer, nil
+	}
+	return nil, fmt.Errorf(`%s: %s`, ErrIdentityNotFound, callerId)
+}
+
+// NewSignerResolver creates resolver, looking up identity in store by caller id,
+// extracted with first callerIds func, which finds credential in request
+func NewSignerResolver(store IdentityStore, callerIds ...CallerIdFunc) SignerResolverFunc {
+	return func(ctx context.Context) (msp.SigningIdentity, error) {
+		for _, callerId := range callerIds {
+			id, err := callerId(ctx)
+			if err == ErrCallerIdNotFound {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			return store.Identity(id)
+		}
+		return nil, ErrCallerIdNotFound
+	}
+}
+
+// APIKeyCallerId returns API key from gRPC metadata (or HTTP header, forwarded by grpc-gateway) as caller id
+func APIKeyCallerId(header string) CallerIdFunc {
+	return func(ctx context.Context) (string, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get(header); len(values) > 0 && values[0] != `` {
+			return values[0], nil
+		}
+		return ``, ErrCallerIdNotFound
+	}
+}
+
+// JWTSubjectCallerId returns subject of bearer token from authorization metadata, verified with verify, as caller id
+func JWTSubjectCallerId(verify JWTVerifier) CallerIdFunc {
+	return func(ctx context.Context) (string, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(AuthorizationHeader)
+		if len(values) == 0 || !strings.HasPrefix(values[0], BearerPrefix) {
+			return ``, ErrCallerIdNotFound
+		}
+
+		subject, err := verify(strings.TrimPrefix(values[0], BearerPrefix))
+		if err != nil {
+			return ``, fmt.Errorf(`%s: %s`, ErrTokenInvalid, err)
+		}
+		return subject, nil
+	}
+}
+
+// ProxyAddr is address of in-process connection of REST proxy to gRPC server (see gateway/server).
+// Proxy presents server certificate, so client certificate of such connection doesn't identify caller
+type ProxyAddr struct{}
+
+func (ProxyAddr) Network() string {
+	return `proxy`
+}
+
+func (ProxyAddr) String() string {
+	return `rest-proxy`
+}
+
+// TLSCertCallerId returns common name of verified client TLS certificate of gRPC connection as caller id.
+// Connections of REST proxy (with ProxyAddr) are skipped
+func TLSCertCallerId() CallerIdFunc {
+	return func(ctx context.Context) (string, error) {
+		p, ok := peer.FromContext(ctx)
+		if !ok {
+			return ``, ErrCallerIdNotFound
+		}
+		if _, isProxy := p.Addr.(ProxyAddr); isProxy {
+			return ``, ErrCallerIdNotFound
+		}
+		tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+		if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+			return ``, ErrCallerIdNotFound
+		}
+		return tlsInfo.State.VerifiedChains[0][0].Subject.CommonName, nil
+	}
+}
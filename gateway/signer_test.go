@@ -0,0 +1,137 @@
+package gateway_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"time"
+
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/peer"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	grpcpeer "google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/optherium/cckit/gateway"
+	"github.com/optherium/cckit/gateway/service"
+	testcc "github.com/optherium/cckit/testing"
+)
+
+// SignerRecordingService records signer of last invoke
+type SignerRecordingService struct {
+	*service.MockChaincodeService
+	Signer msp.SigningIdentity
+}
+
+func (s *SignerRecordingService) Invoke(ctx context.Context, in *service.ChaincodeInput) (*peer.ProposalResponse, error) {
+	s.Signer, _ = service.SignerFromContext(ctx)
+	return s.MockChaincodeService.Invoke(ctx, in)
+}
+
+func hs256Token(secret []byte, claims map[string]interface{}) string {
+	enc := func(v interface{}) string {
+		bb, _ := json.Marshal(v)
+		return base64.RawURLEncoding.EncodeToString(bb)
+	}
+	unsigned := enc(map[string]string{`alg`: `HS256`, `typ`: `JWT`}) + `.` + enc(claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return unsigned + `.` + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func incoming(kv ...string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(kv...))
+}
+
+// withClientCert returns context of gRPC connection from addr with verified client certificate
+func withClientCert(addr net.Addr, commonName string) context.Context {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}}
+	return grpcpeer.NewContext(context.Background(), &grpcpeer.Peer{
+		Addr: addr,
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}},
+	})
+}
+
+var _ = Describe(`Signer resolver`, func() {
+
+	var (
+		alice     = testcc.MustGenerateIdentity(`MSP`, pkix.Name{CommonName: `alice`}, nil)
+		bob       = testcc.MustGenerateIdentity(`MSP`, pkix.Name{CommonName: `bob`}, nil)
+		recording = &SignerRecordingService{MockChaincodeService: service.NewMock().WithChannel(
+			`signer`, testcc.NewMockStub(`emitter`, NewEmitter()))}
+		secret = []byte(`secret`)
+
+		resolver = gateway.NewSignerResolver(
+			gateway.IdentityMap{`alice-key`: alice, `bob`: bob},
+			gateway.APIKeyCallerId(`x-api-key`),
+			gateway.JWTSubjectCallerId(gateway.HS256Verifier(secret)),
+			gateway.TLSCertCallerId())
+
+		emitter = gateway.NewChaincode(recording, `signer`, `emitter`,
+			gateway.WithDefaultSigner(bob), gateway.WithSignerResolver(resolver))
+	)
+
+	It(`Allow to resolve signer by API key`, func() {
+		_, err := emitter.Invoke(incoming(`x-api-key`, `alice-key`), `emit`, []interface{}{`Created`}, ``)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recording.Signer).To(Equal(alice))
+	})
+
+	It(`Allow to resolve signer by JWT subject`, func() {
+		token := hs256Token(secret, map[string]interface{}{`sub`: `bob`, `exp`: time.Now().Add(time.Minute).Unix()})
+		_, err := emitter.Invoke(incoming(`authorization`, `Bearer `+token), `emit`, []interface{}{`Created`}, ``)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recording.Signer).To(Equal(bob))
+	})
+
+	It(`Disallow expired or wrongly signed JWT`, func() {
+		expired := hs256Token(secret, map[string]interface{}{`sub`: `bob`, `exp`: time.Now().Add(-time.Minute).Unix()})
+		forged := hs256Token([]byte(`other`), map[string]interface{}{`sub`: `bob`})
+		notExpiring := hs256Token(secret, map[string]interface{}{`sub`: `bob`})
+
+		for _, token := range []string{expired, forged, notExpiring} {
+			_, err := emitter.Invoke(incoming(`authorization`, `Bearer `+token), `emit`, []interface{}{`Created`}, ``)
+			Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+			Expect(err).To(MatchError(ContainSubstring(gateway.ErrTokenInvalid.Error())))
+		}
+	})
+
+	It(`Allow to resolve signer by client certificate, except REST proxy connections`, func() {
+		for _, ip := range []string{`10.0.0.1`, `127.0.0.1`} {
+			recording.Signer = nil
+			_, err := emitter.Invoke(
+				withClientCert(&net.TCPAddr{IP: net.ParseIP(ip), Port: 5000}, `alice-key`), `emit`, []interface{}{`Created`}, ``)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recording.Signer).To(Equal(alice))
+		}
+
+		_, err := emitter.Invoke(withClientCert(gateway.ProxyAddr{}, `alice-key`), `emit`, []interface{}{`Created`}, ``)
+		Expect(err).To(MatchError(ContainSubstring(gateway.ErrCallerIdNotFound.Error())))
+	})
+
+	It(`Disallow request with unknown caller`, func() {
+		_, err := emitter.Invoke(incoming(`x-api-key`, `unknown`), `emit`, []interface{}{`Created`}, ``)
+		Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+		Expect(err).To(MatchError(ContainSubstring(gateway.ErrIdentityNotFound.Error())))
+	})
+
+	It(`Disallow request without credentials, default signer is not used`, func() {
+		_, err := emitter.Query(context.Background(), `emit`, []interface{}{`Created`}, ``)
+		Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+		Expect(err).To(MatchError(ContainSubstring(gateway.ErrCallerIdNotFound.Error())))
+
+		_, err = emitter.Events(context.Background())
+		Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+	})
+})
@@ -3,6 +3,7 @@ package identity
 
 import (
 	"crypto/x509"
+	"encoding/json"
 
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 )
@@ -23,6 +24,17 @@ type Id struct {
 	Cert string
 }
 
+// FromBytes implements convert.FromByter interface, Id is json serialized
+func (id Id) FromBytes(bb []byte) (interface{}, error) {
+	err := json.Unmarshal(bb, &id)
+	return id, err
+}
+
+// ToBytes implements convert.ToByter interface
+func (id Id) ToBytes() ([]byte, error) {
+	return json.Marshal(id)
+}
+
 // IdentityEntry interface
 type IdentityEntry interface {
 	GetIdentityEntry() Entry
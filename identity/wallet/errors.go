@@ -0,0 +1,30 @@
+package wallet
+
+import "errors"
+
+var (
+	// ErrIdentityNotFound occurs when wallet has no identity with requested label
+	ErrIdentityNotFound = errors.New(`identity not found`)
+
+	// ErrLabelInvalid occurs when label is empty or contains characters other than letters, digits, `.`, `_`, `-`, `@`
+	ErrLabelInvalid = errors.New(`label invalid`)
+
+	ErrMspIdNotDefined = errors.New(`msp id not defined`)
+
+	ErrPemEncodedKeyExpected = errors.New(`expecting a PEM-encoded private key; PEM block not found`)
+
+	// ErrKeyNotSupported occurs when private key is not ECDSA key
+	ErrKeyNotSupported = errors.New(`private key type not supported`)
+
+	// ErrKeyMismatch occurs when private key doesn't match certificate public key
+	ErrKeyMismatch = errors.New(`private key doesn't match certificate`)
+
+	// ErrPassphraseRequired occurs when loading encrypted private key from wallet without passphrase
+	ErrPassphraseRequired = errors.New(`passphrase required`)
+
+	// ErrDecryptionFailed occurs when private key can't be decrypted, i.e. passphrase is wrong
+	ErrDecryptionFailed = errors.New(`private key decryption failed`)
+
+	// ErrFormatVersionUnknown occurs when identity file has unsupported format version
+	ErrFormatVersionUnknown = errors.New(`identity file format version unknown`)
+)
@@ -0,0 +1,163 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric/msp"
+	msppb "github.com/hyperledger/fabric/protos/msp"
+	"github.com/optherium/cckit/identity"
+)
+
+// Identity is wallet entry - X.509 certificate with ECDSA private key, implements msp.SigningIdentity
+type Identity struct {
+	Label       string
+	MspId       string
+	Certificate *x509.Certificate
+	PrivateKey  *ecdsa.PrivateKey
+}
+
+// NewIdentity creates identity from PEM encoded certificate and private key (PKCS8 or SEC1),
+// private key must match certificate public key
+func NewIdentity(label, mspId string, certPEM, keyPEM []byte) (*Identity, error) {
+	if mspId == `` {
+		return nil, ErrMspIdNotDefined
+	}
+	cert, err := identity.Certificate(certPEM)
+	if err != nil {
+		return nil, err
+	}
+	key, err := privateKeyFromPEM(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	pub, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	if !ok || pub.X.Cmp(key.X) != 0 || pub.Y.Cmp(key.Y) != 0 {
+		return nil, ErrKeyMismatch
+	}
+
+	return &Identity{Label: label, MspId: mspId, Certificate: cert, PrivateKey: key}, nil
+}
+
+// copy returns shallow copy of identity, so cached identity fields are not changed by caller
+func (i *Identity) copy() *Identity {
+	cp := *i
+	return &cp
+}
+
+func (i *Identity) Anonymous() bool {
+	return false
+}
+
+// ExpiresAt returns date of certificate expiration
+func (i *Identity) ExpiresAt() time.Time {
+	return i.Certificate.NotAfter
+}
+
+func (i *Identity) GetIdentifier() *msp.IdentityIdentifier {
+	return &msp.IdentityIdentifier{
+		Mspid: i.MspId,
+		Id:    i.Certificate.Subject.CommonName,
+	}
+}
+
+// GetMSPIdentifier returns current MspID of identity
+func (i *Identity) GetMSPIdentifier() string {
+	return i.MspId
+}
+
+func (i *Identity) Validate() error {
+	return nil
+}
+
+func (i *Identity) GetOrganizationalUnits() []*msp.OUIdentifier {
+	return nil
+}
+
+// Verify checks low-S ECDSA signature of SHA-256 message digest with certificate public key
+func (i *Identity) Verify(msg []byte, sig []byte) error {
+	r, s, err := utils.UnmarshalECDSASignature(sig)
+	if err != nil {
+		return err
+	}
+	pub := i.Certificate.PublicKey.(*ecdsa.PublicKey)
+	if lowS, err := utils.IsLowS(pub, s); err != nil || !lowS {
+		return errors.New(`signature is not low-S`)
+	}
+
+	digest := sha256.Sum256(msg)
+	if !ecdsa.Verify(pub, digest[:], r, s) {
+		return errors.New(`signature invalid`)
+	}
+	return nil
+}
+
+func (i *Identity) Serialize() ([]byte, error) {
+	return proto.Marshal(&msppb.SerializedIdentity{Mspid: i.MspId, IdBytes: i.GetPEM()})
+}
+
+func (i *Identity) SatisfiesPrincipal(principal *msppb.MSPPrincipal) error {
+	return nil
+}
+
+// Sign signs SHA-256 message digest, signature is converted to low-S form, as Fabric requires
+func (i *Identity) Sign(msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+	r, s, err := ecdsa.Sign(rand.Reader, i.PrivateKey, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	if s, _, err = utils.ToLowS(&i.PrivateKey.PublicKey, s); err != nil {
+		return nil, err
+	}
+	return utils.MarshalECDSASignature(r, s)
+}
+
+func (i *Identity) GetPublicVersion() msp.Identity {
+	return i
+}
+
+// GetPEM certificate encoded to PEM
+func (i *Identity) GetPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  `CERTIFICATE`,
+		Bytes: i.Certificate.Raw,
+	})
+}
+
+// GetKeyPEM private key encoded to PKCS8 PEM
+func (i *Identity) GetKeyPEM() ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(i.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: `PRIVATE KEY`, Bytes: der}), nil
+}
+
+func privateKeyFromPEM(keyPEM []byte) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, ErrPemEncodedKeyExpected
+	}
+
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, ErrKeyNotSupported
+	}
+	return ecKey, nil
+}
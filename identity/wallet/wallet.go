@@ -0,0 +1,423 @@
+// Package wallet stores labelled Fabric signing identities (MSP id, certificate and private key) in directory
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric/msp"
+)
+
+const (
+	// FileExt identity file extension, file name without extension is identity label
+	FileExt = `.id`
+	// PreviousFileExt extension of identity file, replaced by last rotation
+	PreviousFileExt = FileExt + `.previous`
+
+	FormatVersion = 1
+	TypeX509      = `X.509`
+)
+
+var labelRegexp = regexp.MustCompile(`^[\w.@-]+$`)
+
+type (
+	Opt func(*Wallet)
+
+	// Wallet stores each identity as JSON file <label>.id in directory,
+	// private keys are encrypted at rest if wallet has passphrase.
+	// Decrypted identities are cached, so identity files must be changed only via wallet
+	Wallet struct {
+		dir        string
+		passphrase []byte
+		cache      map[string]*Identity
+		mu         sync.RWMutex
+	}
+
+	identityFile struct {
+		Version     int         `json:"version"`
+		MspId       string      `json:"mspId"`
+		Type        string      `json:"type"`
+		Credentials credentials `json:"credentials"`
+	}
+
+	credentials struct {
+		Certificate         string     `json:"certificate"`
+		PrivateKey          string     `json:"privateKey,omitempty"`
+		EncryptedPrivateKey *sealedKey `json:"encryptedPrivateKey,omitempty"`
+	}
+)
+
+// WithPassphrase encrypts private keys, stored to wallet, with key derived from passphrase
+func WithPassphrase(passphrase []byte) Opt {
+	return func(w *Wallet) {
+		w.passphrase = passphrase
+	}
+}
+
+// New opens wallet in dir, creating directory if not exists
+func New(dir string, opts ...Opt) (*Wallet, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	w := &Wallet{dir: dir, cache: make(map[string]*Identity)}
+	for _, o := range opts {
+		o(w)
+	}
+	return w, nil
+}
+
+// Put stores identity with label, replacing existing one
+func (w *Wallet) Put(label string, id *Identity) error {
+	if err := checkLabel(label); err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.cache, label)
+	return w.write(label, id)
+}
+
+// Import stores identity from PEM encoded certificate and private key
+func (w *Wallet) Import(label, mspId string, certPEM, keyPEM []byte) (*Identity, error) {
+	id, err := NewIdentity(label, mspId, certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	return id, w.Put(label, id)
+}
+
+// ImportFiles stores identity from PEM encoded certificate and private key files
+func (w *Wallet) ImportFiles(label, mspId, certFile, keyFile string) (*Identity, error) {
+	certPEM, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	return w.Import(label, mspId, certPEM, keyPEM)
+}
+
+// Get loads identity by label, returns ErrIdentityNotFound if wallet has no identity with label.
+// Decrypted identity is cached until it is replaced or removed via wallet
+func (w *Wallet) Get(label string) (*Identity, error) {
+	if err := checkLabel(label); err != nil {
+		return nil, err
+	}
+
+	w.mu.RLock()
+	id, ok := w.cache[label]
+	w.mu.RUnlock()
+	if ok {
+		return id.copy(), nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if id, ok = w.cache[label]; ok {
+		return id.copy(), nil
+	}
+	id, err := w.read(label)
+	if err != nil {
+		return nil, err
+	}
+	w.cache[label] = id
+	return id.copy(), nil
+}
+
+// Identity returns signing identity by label, so wallet can be used as gateway.IdentityStore
+func (w *Wallet) Identity(label string) (msp.SigningIdentity, error) {
+	id, err := w.Get(label)
+	if err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+// Export returns PEM encoded certificate and decrypted PKCS8 private key of identity
+func (w *Wallet) Export(label string) (certPEM, keyPEM []byte, err error) {
+	id, err := w.Get(label)
+	if err != nil {
+		return nil, nil, err
+	}
+	if keyPEM, err = id.GetKeyPEM(); err != nil {
+		return nil, nil, err
+	}
+	return id.GetPEM(), keyPEM, nil
+}
+
+// List returns sorted labels of stored identities
+func (w *Wallet) List() ([]string, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.list(FileExt)
+}
+
+// Remove deletes identity by label
+func (w *Wallet) Remove(label string) error {
+	if err := checkLabel(label); err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.cache, label)
+
+	err := os.Remove(w.path(label))
+	if os.IsNotExist(err) {
+		return fmt.Errorf(`%s: %s`, ErrIdentityNotFound, label)
+	}
+	return err
+}
+
+// Rotate replaces certificate and private key of existing identity, keeping its label and MSP id.
+// Replaced identity file is kept as <label>.id.previous
+func (w *Wallet) Rotate(label string, certPEM, keyPEM []byte) (*Identity, error) {
+	if err := checkLabel(label); err != nil {
+		return nil, err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.cache, label)
+
+	current, err := w.read(label)
+	if err != nil {
+		return nil, err
+	}
+	id, err := NewIdentity(label, current.MspId, certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = copyFile(w.path(label), w.previousPath(label)); err != nil {
+		return nil, err
+	}
+	return id, w.write(label, id)
+}
+
+// ChangePassphrase re-encrypts private keys of all stored identities, including previous identities kept by Rotate,
+// with new passphrase. With empty passphrase private keys are stored unencrypted
+func (w *Wallet) ChangePassphrase(passphrase []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var paths, labels []string
+	for _, ext := range []string{FileExt, PreviousFileExt} {
+		extLabels, err := w.list(ext)
+		if err != nil {
+			return err
+		}
+		for _, label := range extLabels {
+			paths = append(paths, filepath.Join(w.dir, label+ext))
+			labels = append(labels, label)
+		}
+	}
+
+	// all identities are decrypted and re-encrypted to temp files before renaming, so wrong current passphrase
+	// or write error doesn't change wallet. If renaming fails, already renamed files are restored from originals
+	tmps := make([]string, 0, len(paths))
+	originals := make([][]byte, 0, len(paths))
+	defer func() {
+		for _, tmp := range tmps {
+			_ = os.Remove(tmp)
+		}
+	}()
+	for i, path := range paths {
+		original, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		originals = append(originals, original)
+
+		id, err := readFile(path, labels[i], w.passphrase)
+		if err != nil {
+			return err
+		}
+		bb, err := encode(labels[i], id, passphrase)
+		if err != nil {
+			return err
+		}
+		tmp, err := tempFile(path, bb)
+		if err != nil {
+			return err
+		}
+		tmps = append(tmps, tmp)
+	}
+
+	for i, tmp := range tmps {
+		if err := os.Rename(tmp, paths[i]); err != nil {
+			if restoreErr := restoreFiles(paths[:i], originals[:i]); restoreErr != nil {
+				return fmt.Errorf(`%s, restore: %s`, err, restoreErr)
+			}
+			return err
+		}
+	}
+	tmps = nil
+
+	w.passphrase = passphrase
+	w.cache = make(map[string]*Identity)
+	return nil
+}
+
+// restoreFiles atomically writes original content to files
+func restoreFiles(paths []string, originals [][]byte) error {
+	for i, path := range paths {
+		tmp, err := tempFile(path, originals[i])
+		if err != nil {
+			return err
+		}
+		if err = os.Rename(tmp, path); err != nil {
+			_ = os.Remove(tmp)
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *Wallet) path(label string) string {
+	return filepath.Join(w.dir, label+FileExt)
+}
+
+func (w *Wallet) previousPath(label string) string {
+	return filepath.Join(w.dir, label+PreviousFileExt)
+}
+
+// list returns sorted labels of files with ext
+func (w *Wallet) list(ext string) ([]string, error) {
+	files, err := ioutil.ReadDir(w.dir)
+	if err != nil {
+		return nil, err
+	}
+	var labels []string
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ext) {
+			continue
+		}
+		// temp files, written by wallet, are skipped as labels can't start with dot
+		label := strings.TrimSuffix(f.Name(), ext)
+		if checkLabel(label) != nil {
+			continue
+		}
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels, nil
+}
+
+func (w *Wallet) read(label string) (*Identity, error) {
+	return readFile(w.path(label), label, w.passphrase)
+}
+
+// readFile loads identity from file, decrypting private key with passphrase
+func readFile(path, label string, passphrase []byte) (*Identity, error) {
+	bb, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf(`%s: %s`, ErrIdentityNotFound, label)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	file := &identityFile{}
+	if err = json.Unmarshal(bb, file); err != nil {
+		return nil, fmt.Errorf(`identity %s: %s`, label, err)
+	}
+	if file.Version != FormatVersion {
+		return nil, fmt.Errorf(`%s: %d`, ErrFormatVersionUnknown, file.Version)
+	}
+
+	keyPEM := []byte(file.Credentials.PrivateKey)
+	if sealed := file.Credentials.EncryptedPrivateKey; sealed != nil {
+		if len(passphrase) == 0 {
+			return nil, fmt.Errorf(`%s: %s`, ErrPassphraseRequired, label)
+		}
+		ad := additionalData(label, file.Credentials.Certificate)
+		if keyPEM, err = sealed.open(passphrase, ad); err != nil {
+			return nil, fmt.Errorf(`%s: %s`, err, label)
+		}
+	}
+	return NewIdentity(label, file.MspId, []byte(file.Credentials.Certificate), keyPEM)
+}
+
+func (w *Wallet) write(label string, id *Identity) error {
+	bb, err := encode(label, id, w.passphrase)
+	if err != nil {
+		return err
+	}
+	return writeFile(w.path(label), bb)
+}
+
+// encode serializes identity, stored with label, to JSON, encrypting private key with passphrase if not empty
+func encode(label string, id *Identity, passphrase []byte) ([]byte, error) {
+	keyPEM, err := id.GetKeyPEM()
+	if err != nil {
+		return nil, err
+	}
+
+	file := &identityFile{
+		Version:     FormatVersion,
+		MspId:       id.MspId,
+		Type:        TypeX509,
+		Credentials: credentials{Certificate: string(id.GetPEM())},
+	}
+	if len(passphrase) > 0 {
+		ad := additionalData(label, file.Credentials.Certificate)
+		if file.Credentials.EncryptedPrivateKey, err = seal(passphrase, keyPEM, ad); err != nil {
+			return nil, err
+		}
+	} else {
+		file.Credentials.PrivateKey = string(keyPEM)
+	}
+
+	return json.MarshalIndent(file, ``, `  `)
+}
+
+func checkLabel(label string) error {
+	if !labelRegexp.MatchString(label) || strings.HasPrefix(label, `.`) {
+		return fmt.Errorf(`%s: %s`, ErrLabelInvalid, label)
+	}
+	return nil
+}
+
+// writeFile writes to temp file and renames it, so identity file is never partially written
+func writeFile(path string, data []byte) error {
+	tmp, err := tempFile(path, data)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp) }()
+	return os.Rename(tmp, path)
+}
+
+// tempFile writes data to temp file in dir of path, returns temp file name
+func tempFile(path string, data []byte) (string, error) {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), `.`+filepath.Base(path))
+	if err != nil {
+		return ``, err
+	}
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return ``, err
+	}
+	if err = tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return ``, err
+	}
+	return tmp.Name(), nil
+}
+
+func copyFile(src, dst string) error {
+	bb, err := ioutil.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return writeFile(dst, bb)
+}
@@ -0,0 +1,299 @@
+package wallet_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/msp"
+	msppb "github.com/hyperledger/fabric/protos/msp"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/optherium/cckit/examples/cert"
+	"github.com/optherium/cckit/gateway"
+	"github.com/optherium/cckit/identity/wallet"
+	testcc "github.com/optherium/cckit/testing"
+)
+
+func TestWallet(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Wallet suite")
+}
+
+var (
+	_ msp.SigningIdentity   = &wallet.Identity{}
+	_ gateway.IdentityStore = &wallet.Wallet{}
+)
+
+func mustContent(file string) []byte {
+	content, err := cert.Content(file)
+	if err != nil {
+		panic(err)
+	}
+	return content
+}
+
+var _ = Describe(`Wallet`, func() {
+
+	var (
+		dir string
+		w   *wallet.Wallet
+
+		ownerCert = mustContent(`s7techlab.pem`)
+		ownerKey  = mustContent(`s7techlab.key.pem`)
+		otherCert = mustContent(`some-person.pem`)
+		otherKey  = mustContent(`some-person.key.pem`)
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir(``, `wallet`)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(dir)
+	})
+
+	Context(`Plain`, func() {
+
+		BeforeEach(func() {
+			var err error
+			w, err = wallet.New(dir)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It(`Allow to import, list and get identity`, func() {
+			_, err := w.Import(`owner`, `SOME_MSP`, ownerCert, ownerKey)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = w.Import(`other`, `OTHER_MSP`, otherCert, otherKey)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(w.List()).To(Equal([]string{`other`, `owner`}))
+
+			id, err := w.Get(`owner`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id.GetMSPIdentifier()).To(Equal(`SOME_MSP`))
+			Expect(id.GetPEM()).To(Equal(ownerCert))
+		})
+
+		It(`Disallow to import key not matching certificate`, func() {
+			_, err := w.Import(`owner`, `SOME_MSP`, ownerCert, otherKey)
+			Expect(err).To(MatchError(wallet.ErrKeyMismatch))
+		})
+
+		It(`Disallow invalid labels`, func() {
+			for _, label := range []string{``, `../owner`, `.owner`, `a/b`} {
+				_, err := w.Import(label, `SOME_MSP`, ownerCert, ownerKey)
+				Expect(err).To(HaveOccurred())
+			}
+		})
+
+		It(`Return not found error for unknown label`, func() {
+			_, err := w.Get(`unknown`)
+			Expect(err.Error()).To(ContainSubstring(wallet.ErrIdentityNotFound.Error()))
+		})
+
+		It(`Allow to sign and verify with identity`, func() {
+			_, err := w.Import(`owner`, `SOME_MSP`, ownerCert, ownerKey)
+			Expect(err).NotTo(HaveOccurred())
+			id, err := w.Get(`owner`)
+			Expect(err).NotTo(HaveOccurred())
+
+			sig, err := id.Sign([]byte(`message`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id.Verify([]byte(`message`), sig)).To(Succeed())
+			Expect(id.Verify([]byte(`other message`), sig)).NotTo(Succeed())
+		})
+
+		It(`Allow to export identity`, func() {
+			_, err := w.Import(`owner`, `SOME_MSP`, ownerCert, ownerKey)
+			Expect(err).NotTo(HaveOccurred())
+
+			certPEM, keyPEM, err := w.Export(`owner`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(certPEM).To(Equal(ownerCert))
+
+			// exported key can be imported again
+			_, err = w.Import(`copy`, `SOME_MSP`, certPEM, keyPEM)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It(`Allow to rotate identity credentials, keeping previous`, func() {
+			_, err := w.Import(`owner`, `SOME_MSP`, ownerCert, ownerKey)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = w.Rotate(`owner`, otherCert, ownerKey)
+			Expect(err).To(MatchError(wallet.ErrKeyMismatch))
+
+			rotated, err := w.Rotate(`owner`, otherCert, otherKey)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rotated.GetMSPIdentifier()).To(Equal(`SOME_MSP`))
+
+			id, err := w.Get(`owner`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id.GetPEM()).To(Equal(otherCert))
+
+			Expect(filepath.Join(dir, `owner`+wallet.PreviousFileExt)).To(BeAnExistingFile())
+			Expect(w.List()).To(Equal([]string{`owner`}))
+		})
+
+		It(`Allow to remove identity`, func() {
+			_, err := w.Import(`owner`, `SOME_MSP`, ownerCert, ownerKey)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(w.Remove(`owner`)).To(Succeed())
+			Expect(w.List()).To(BeEmpty())
+			Expect(w.Remove(`owner`)).NotTo(Succeed())
+		})
+	})
+
+	Context(`Encrypted`, func() {
+
+		BeforeEach(func() {
+			var err error
+			w, err = wallet.New(dir, wallet.WithPassphrase([]byte(`passphrase`)))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = w.Import(`owner`, `SOME_MSP`, ownerCert, ownerKey)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It(`Store private key encrypted`, func() {
+			content, err := ioutil.ReadFile(filepath.Join(dir, `owner`+wallet.FileExt))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).NotTo(ContainSubstring(`PRIVATE KEY`))
+
+			id, err := w.Get(`owner`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id.GetPEM()).To(Equal(ownerCert))
+		})
+
+		It(`Disallow to get identity without passphrase or with wrong passphrase`, func() {
+			noPassphrase, _ := wallet.New(dir)
+			_, err := noPassphrase.Get(`owner`)
+			Expect(err.Error()).To(ContainSubstring(wallet.ErrPassphraseRequired.Error()))
+
+			wrongPassphrase, _ := wallet.New(dir, wallet.WithPassphrase([]byte(`wrong`)))
+			_, err = wrongPassphrase.Get(`owner`)
+			Expect(err.Error()).To(ContainSubstring(wallet.ErrDecryptionFailed.Error()))
+
+			// labels are available without passphrase
+			Expect(noPassphrase.List()).To(Equal([]string{`owner`}))
+		})
+
+		It(`Allow to change passphrase`, func() {
+			Expect(w.ChangePassphrase([]byte(`new passphrase`))).To(Succeed())
+
+			oldPassphrase, _ := wallet.New(dir, wallet.WithPassphrase([]byte(`passphrase`)))
+			_, err := oldPassphrase.Get(`owner`)
+			Expect(err).To(HaveOccurred())
+
+			newPassphrase, _ := wallet.New(dir, wallet.WithPassphrase([]byte(`new passphrase`)))
+			_, err = newPassphrase.Get(`owner`)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It(`Allow to change passphrase of previous identities`, func() {
+			_, err := w.Rotate(`owner`, otherCert, otherKey)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(w.ChangePassphrase([]byte(`new passphrase`))).To(Succeed())
+
+			// previous identity file is restored as current to other wallet dir
+			restoredDir, err := ioutil.TempDir(``, `wallet`)
+			Expect(err).NotTo(HaveOccurred())
+			defer func() { _ = os.RemoveAll(restoredDir) }()
+			previous, err := ioutil.ReadFile(filepath.Join(dir, `owner`+wallet.PreviousFileExt))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ioutil.WriteFile(filepath.Join(restoredDir, `owner`+wallet.FileExt), previous, 0600)).To(Succeed())
+
+			restored, _ := wallet.New(restoredDir, wallet.WithPassphrase([]byte(`new passphrase`)))
+			id, err := restored.Get(`owner`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id.GetPEM()).To(Equal(ownerCert))
+		})
+
+		It(`Disallow to change passphrase with wrong current passphrase, wallet is not changed`, func() {
+			wrongPassphrase, _ := wallet.New(dir, wallet.WithPassphrase([]byte(`wrong`)))
+			Expect(wrongPassphrase.ChangePassphrase([]byte(`new passphrase`))).NotTo(Succeed())
+
+			_, err := w.Get(`owner`)
+			Expect(err).NotTo(HaveOccurred())
+
+			files, err := ioutil.ReadDir(dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(HaveLen(1))
+		})
+
+		It(`Disallow to use encrypted private key with other label`, func() {
+			content, err := ioutil.ReadFile(filepath.Join(dir, `owner`+wallet.FileExt))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ioutil.WriteFile(filepath.Join(dir, `copy`+wallet.FileExt), content, 0600)).To(Succeed())
+
+			_, err = w.Get(`copy`)
+			Expect(err.Error()).To(ContainSubstring(wallet.ErrDecryptionFailed.Error()))
+		})
+
+		It(`Allow to get cached identity, cache is invalidated on put and remove`, func() {
+			_, err := w.Get(`owner`)
+			Expect(err).NotTo(HaveOccurred())
+
+			other, err := wallet.NewIdentity(`owner`, `SOME_MSP`, otherCert, otherKey)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(w.Put(`owner`, other)).To(Succeed())
+			id, err := w.Get(`owner`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id.GetPEM()).To(Equal(otherCert))
+
+			Expect(w.Remove(`owner`)).To(Succeed())
+			_, err = w.Get(`owner`)
+			Expect(err.Error()).To(ContainSubstring(wallet.ErrIdentityNotFound.Error()))
+		})
+	})
+
+	Context(`Usage`, func() {
+
+		BeforeEach(func() {
+			var err error
+			w, err = wallet.New(dir)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = w.Import(`owner`, `SOME_MSP`, ownerCert, ownerKey)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It(`Allow to use identity as mock stub tx creator`, func() {
+			id, err := w.Get(`owner`)
+			Expect(err).NotTo(HaveOccurred())
+
+			stub := testcc.NewMockStub(`cc`, nil).From(id)
+			creator, err := stub.GetCreator()
+			Expect(err).NotTo(HaveOccurred())
+
+			sid := &msppb.SerializedIdentity{}
+			Expect(proto.Unmarshal(creator, sid)).To(Succeed())
+			Expect(sid.Mspid).To(Equal(`SOME_MSP`))
+			Expect(sid.IdBytes).To(Equal(ownerCert))
+		})
+
+		It(`Allow to use wallet as gateway identity store`, func() {
+			resolver := gateway.NewSignerResolver(w, gateway.APIKeyCallerId(`x-api-key`))
+
+			signer, err := resolver.Resolve(
+				metadata.NewIncomingContext(context.Background(), metadata.Pairs(`x-api-key`, `owner`)))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(signer.GetMSPIdentifier()).To(Equal(`SOME_MSP`))
+
+			_, err = resolver.Resolve(
+				metadata.NewIncomingContext(context.Background(), metadata.Pairs(`x-api-key`, `unknown`)))
+			Expect(err).To(HaveOccurred())
+
+			id, err := w.Identity(`unknown`)
+			Expect(err).To(HaveOccurred())
+			Expect(id == nil).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,410 @@
+package router
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/peer"
+	. "github.com/optherium/cckit/errors"
+)
+
+const (
+	// BatchMethod path of built-in batch invoke, relative to group prefix
+	BatchMethod = `batch`
+
+	// BatchEventName name of event, containing events from all batch calls
+	BatchEventName = `batch`
+)
+
+var (
+	// ErrBatchCallFailed occurs when one of batch calls returns error, batch is aborted
+	ErrBatchCallFailed = NewCCError(shim.ERROR, `BATCH_CALL_FAILED`, `batch call failed`)
+
+	// ErrBatchInvalid occurs when batch calls can't be decoded, batch is nested or contains init or query call
+	ErrBatchInvalid = NewCCError(400, `BATCH_INVALID`, `batch invalid`)
+
+	// ErrBatchNotSupported occurs when batch call uses stub method, which result can't include buffered writes
+	// (rich and paginated queries, history, private data ranges and queries)
+	ErrBatchNotSupported = NewCCError(400, `BATCH_NOT_SUPPORTED`, `not supported in batch`)
+
+	// ErrBatchIteratorEnd occurs when Next called on exhausted iterator
+	ErrBatchIteratorEnd = stderrors.New(`batch iterator has no next entry`)
+)
+
+type (
+	// BatchCall one method call in batch, path is full method path
+	BatchCall struct {
+		Path string   `json:"path"`
+		Args [][]byte `json:"args,omitempty"`
+	}
+
+	// BatchEvent event, set by batch call
+	BatchEvent struct {
+		Call    int    `json:"call"`
+		Name    string `json:"name"`
+		Payload []byte `json:"payload,omitempty"`
+	}
+
+	// batchStub buffers state and private data changes and events of batch calls
+	batchStub struct {
+		shim.ChaincodeStubInterface
+		args          [][]byte
+		writes        map[string][]byte            // nil value - deleted key
+		privateWrites map[string]map[string][]byte // collection => key => value
+		event         *BatchEvent
+	}
+
+	batchIterator struct {
+		kvs []*queryresult.KV
+		pos int
+	}
+)
+
+// Batch adds built-in batch invoke. Batch takes json encoded list of {path, args} calls,
+// runs each call through pre, group and handler middleware with shared transactional state
+// and returns json encoded list of responses. If any call fails, batch is aborted.
+// Init and query methods can't be called in batch.
+// Events from all calls are merged into one BatchEventName event.
+func (g *Group) Batch() *Group {
+	g.contextHandlers[g.prefix+BatchMethod] = g.handleBatch
+	return g
+}
+
+func (g *Group) handleBatch(c Context) peer.Response {
+	if _, nested := c.Stub().(*batchStub); nested {
+		return ErrBatchInvalid.Errorf(`nested batch`).Response()
+	}
+	args := c.GetArgs()
+	if len(args) != 2 {
+		return ErrBatchInvalid.Errorf(`calls list expected`).Response()
+	}
+
+	var calls []BatchCall
+	if err := json.Unmarshal(args[1], &calls); err != nil {
+		return ErrBatchInvalid.Errorf(`%s`, err).Response()
+	}
+
+	for _, call := range calls {
+		if err := g.checkBatchCall(call); err != nil {
+			return err.Response()
+		}
+	}
+
+	stub := &batchStub{
+		ChaincodeStubInterface: c.Stub(),
+		writes:                 make(map[string][]byte),
+		privateWrites:          make(map[string]map[string][]byte),
+	}
+	responses := make([]peer.Response, len(calls))
+	var events []BatchEvent
+	// same pre middleware chain as for tx
+	h := g.buildHandler()
+
+	for i, call := range calls {
+		stub.args = append([][]byte{[]byte(call.Path)}, call.Args...)
+		stub.event = nil
+
+		responses[i] = h(forkContext(c, stub).ReplaceArgs(stub.args))
+		if responses[i].Status >= shim.ERRORTHRESHOLD {
+			return batchCallError(i, call.Path, responses[i])
+		}
+		if stub.event != nil {
+			stub.event.Call = i
+			events = append(events, *stub.event)
+		}
+	}
+
+	if err := stub.flush(events); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	payload, err := json.Marshal(responses)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(payload)
+}
+
+// checkBatchCall rejects nested batch, init and query calls
+func (g *Group) checkBatchCall(call BatchCall) *CCError {
+	switch {
+	case call.Path == g.prefix+BatchMethod:
+		return ErrBatchInvalid.Errorf(`nested batch`)
+	case call.Path == InitFunc:
+		return ErrBatchInvalid.Errorf(`init call`)
+	}
+	if handler, ok := g.handlers[call.Path]; ok && handler.Type == MethodQuery {
+		return ErrBatchInvalid.Errorf(`query call: %s`, call.Path)
+	}
+	return nil
+}
+
+func batchCallError(i int, path string, resp peer.Response) peer.Response {
+	ccErr := ErrBatchCallFailed
+	if e, ok := FromResponse(resp).(*CCError); ok && e.Code != 0 {
+		ccErr = e
+	}
+	ccErr = ccErr.
+		WithDetail(`batchCall`, strconv.Itoa(i)).
+		WithDetail(`batchPath`, path)
+	return ccErr.Response()
+}
+
+// forkContext creates context for batch call, sharing id counter with batch context and
+// copying params and stored values. State and event are not copied, they are bound to batch stub
+func forkContext(c Context, stub shim.ChaincodeStubInterface) Context {
+	parent, ok := c.(*context)
+	if !ok {
+		return NewContext(stub, c.Logger())
+	}
+
+	fork := &context{stub: stub, logger: parent.logger, idCounter: parent.counter()}
+	for k, v := range parent.params {
+		fork.SetParam(k, v)
+	}
+	for k, v := range parent.store {
+		fork.Set(k, v)
+	}
+	return fork
+}
+
+func (s *batchStub) GetArgs() [][]byte {
+	return s.args
+}
+
+func (s *batchStub) GetStringArgs() []string {
+	args := make([]string, len(s.args))
+	for i, a := range s.args {
+		args[i] = string(a)
+	}
+	return args
+}
+
+func (s *batchStub) GetFunctionAndParameters() (string, []string) {
+	args := s.GetStringArgs()
+	return args[0], args[1:]
+}
+
+func (s *batchStub) GetState(key string) ([]byte, error) {
+	if value, ok := s.writes[key]; ok {
+		return value, nil
+	}
+	return s.ChaincodeStubInterface.GetState(key)
+}
+
+func (s *batchStub) PutState(key string, value []byte) error {
+	if key == `` {
+		return ErrUnableToCreateStateKey
+	}
+	if value == nil {
+		value = []byte{}
+	}
+	s.writes[key] = value
+	return nil
+}
+
+func (s *batchStub) DelState(key string) error {
+	s.writes[key] = nil
+	return nil
+}
+
+func (s *batchStub) SetEvent(name string, payload []byte) error {
+	s.event = &BatchEvent{Name: name, Payload: payload}
+	return nil
+}
+
+func (s *batchStub) GetPrivateData(collection, key string) ([]byte, error) {
+	if value, ok := s.privateWrites[collection][key]; ok {
+		return value, nil
+	}
+	return s.ChaincodeStubInterface.GetPrivateData(collection, key)
+}
+
+func (s *batchStub) PutPrivateData(collection, key string, value []byte) error {
+	if key == `` {
+		return ErrUnableToCreateStateKey
+	}
+	if value == nil {
+		value = []byte{}
+	}
+	s.privateWrite(collection, key, value)
+	return nil
+}
+
+func (s *batchStub) DelPrivateData(collection, key string) error {
+	s.privateWrite(collection, key, nil)
+	return nil
+}
+
+func (s *batchStub) privateWrite(collection, key string, value []byte) {
+	if s.privateWrites[collection] == nil {
+		s.privateWrites[collection] = make(map[string][]byte)
+	}
+	s.privateWrites[collection][key] = value
+}
+
+func (s *batchStub) GetStateByRangeWithPagination(string, string, int32, string) (
+	shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	return nil, nil, ErrBatchNotSupported.Errorf(`GetStateByRangeWithPagination`)
+}
+
+func (s *batchStub) GetStateByPartialCompositeKeyWithPagination(string, []string, int32, string) (
+	shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	return nil, nil, ErrBatchNotSupported.Errorf(`GetStateByPartialCompositeKeyWithPagination`)
+}
+
+func (s *batchStub) GetQueryResult(string) (shim.StateQueryIteratorInterface, error) {
+	return nil, ErrBatchNotSupported.Errorf(`GetQueryResult`)
+}
+
+func (s *batchStub) GetQueryResultWithPagination(string, int32, string) (
+	shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	return nil, nil, ErrBatchNotSupported.Errorf(`GetQueryResultWithPagination`)
+}
+
+func (s *batchStub) GetHistoryForKey(string) (shim.HistoryQueryIteratorInterface, error) {
+	return nil, ErrBatchNotSupported.Errorf(`GetHistoryForKey`)
+}
+
+func (s *batchStub) GetPrivateDataByRange(string, string, string) (shim.StateQueryIteratorInterface, error) {
+	return nil, ErrBatchNotSupported.Errorf(`GetPrivateDataByRange`)
+}
+
+func (s *batchStub) GetPrivateDataByPartialCompositeKey(string, string, []string) (shim.StateQueryIteratorInterface, error) {
+	return nil, ErrBatchNotSupported.Errorf(`GetPrivateDataByPartialCompositeKey`)
+}
+
+func (s *batchStub) GetPrivateDataQueryResult(string, string) (shim.StateQueryIteratorInterface, error) {
+	return nil, ErrBatchNotSupported.Errorf(`GetPrivateDataQueryResult`)
+}
+
+func (s *batchStub) GetStateByRange(startKey, endKey string) (shim.StateQueryIteratorInterface, error) {
+	iter, err := s.ChaincodeStubInterface.GetStateByRange(startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	return s.mergeWrites(iter, startKey, endKey)
+}
+
+func (s *batchStub) GetStateByPartialCompositeKey(objectType string, keys []string) (shim.StateQueryIteratorInterface, error) {
+	iter, err := s.ChaincodeStubInterface.GetStateByPartialCompositeKey(objectType, keys)
+	if err != nil {
+		return nil, err
+	}
+	prefix, err := s.CreateCompositeKey(objectType, keys)
+	if err != nil {
+		return nil, err
+	}
+	return s.mergeWrites(iter, prefix, prefix+string(utf8.MaxRune))
+}
+
+// mergeWrites returns iterator over ledger entries from iter and buffered writes in key range
+func (s *batchStub) mergeWrites(iter shim.StateQueryIteratorInterface, startKey, endKey string) (*batchIterator, error) {
+	defer func() { _ = iter.Close() }()
+
+	entries := make(map[string][]byte)
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		entries[kv.Key] = kv.Value
+	}
+
+	for key, value := range s.writes {
+		if key < startKey || (endKey != `` && key >= endKey) {
+			continue
+		}
+		if value == nil {
+			delete(entries, key)
+		} else {
+			entries[key] = value
+		}
+	}
+
+	merged := &batchIterator{}
+	for key, value := range entries {
+		merged.kvs = append(merged.kvs, &queryresult.KV{Key: key, Value: value})
+	}
+	sort.Slice(merged.kvs, func(i, j int) bool { return merged.kvs[i].Key < merged.kvs[j].Key })
+	return merged, nil
+}
+
+// flush applies buffered writes, private data writes and batch event to underlying stub
+func (s *batchStub) flush(events []BatchEvent) error {
+	keys := make([]string, 0, len(s.writes))
+	for key := range s.writes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		var err error
+		if s.writes[key] == nil {
+			err = s.ChaincodeStubInterface.DelState(key)
+		} else {
+			err = s.ChaincodeStubInterface.PutState(key, s.writes[key])
+		}
+		if err != nil {
+			return fmt.Errorf(`batch flush %s: %s`, key, err)
+		}
+	}
+
+	collections := make([]string, 0, len(s.privateWrites))
+	for collection := range s.privateWrites {
+		collections = append(collections, collection)
+	}
+	sort.Strings(collections)
+
+	for _, collection := range collections {
+		writes := s.privateWrites[collection]
+		keys = keys[:0]
+		for key := range writes {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			var err error
+			if writes[key] == nil {
+				err = s.ChaincodeStubInterface.DelPrivateData(collection, key)
+			} else {
+				err = s.ChaincodeStubInterface.PutPrivateData(collection, key, writes[key])
+			}
+			if err != nil {
+				return fmt.Errorf(`batch flush %s/%s: %s`, collection, key, err)
+			}
+		}
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+	payload, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	return s.ChaincodeStubInterface.SetEvent(BatchEventName, payload)
+}
+
+func (i *batchIterator) HasNext() bool {
+	return i.pos < len(i.kvs)
+}
+
+func (i *batchIterator) Next() (*queryresult.KV, error) {
+	if !i.HasNext() {
+		return nil, ErrBatchIteratorEnd
+	}
+	i.pos++
+	return i.kvs[i.pos-1], nil
+}
+
+func (i *batchIterator) Close() error {
+	return nil
+}
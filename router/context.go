@@ -2,12 +2,18 @@ package router
 
 import (
 	"fmt"
+	"math/big"
+	"math/rand"
+	"reflect"
 	"runtime"
 	"strings"
 	"time"
 
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/core/chaincode/lib/cid"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/optherium/cckit/convert"
+	"github.com/optherium/cckit/identity"
 	"github.com/optherium/cckit/state"
 )
 
@@ -34,6 +40,12 @@ type (
 		// Time returns txTimesta
 		Time() (time.Time, error)
 
+		// NewID returns deterministic, ordered unique id, derived from tx id, tx timestamp and per-tx counter
+		NewID() (string, error)
+
+		// DeterministicRand returns pseudo random generator, seeded with tx id and per-tx counter
+		DeterministicRand() (*rand.Rand, error)
+
 		ReplaceArgs(args [][]byte) Context // replace args, for usage in preMiddleware
 		GetArgs() [][]byte
 
@@ -70,6 +82,24 @@ type (
 		// ParamInt returns parameter value as bytes.
 		ParamInt(name string) int
 
+		// ParamInt64 returns parameter value as int64.
+		ParamInt64(name string) int64
+
+		// ParamUint64 returns parameter value as uint64.
+		ParamUint64(name string) uint64
+
+		// ParamBigInt returns parameter value as *big.Int.
+		ParamBigInt(name string) *big.Int
+
+		// ParamDecimal returns parameter value as fixed-point decimal.
+		ParamDecimal(name string) convert.Decimal
+
+		// ParamTime returns parameter value (time.Time or protobuf timestamp) as time.Time.
+		ParamTime(name string) time.Time
+
+		// ParamIdentity returns parameter value as identity.Id.
+		ParamIdentity(name string) identity.Id
+
 		// SetParam sets parameter value.
 		SetParam(name string, value interface{})
 
@@ -101,6 +131,9 @@ type (
 		args    [][]byte
 		params  InterfaceMap
 		store   InterfaceMap
+
+		// per-tx counter for deterministic ids, shared between batch calls
+		idCounter *uint32
 	}
 )
 
@@ -221,8 +254,18 @@ func (c *context) ArgString(name string) string {
 }
 
 func (c *context) ParamString(name string) string {
-	out, _ := c.Param(name).(string)
-	return out
+	switch v := c.Param(name).(type) {
+	case string:
+		return v
+	case nil:
+		return ``
+	default:
+		// string based types, i.e. enums
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.String {
+			return rv.String()
+		}
+		return ``
+	}
 }
 
 // Deprecated: Use ParamBytes instead.
@@ -245,6 +288,42 @@ func (c *context) ParamInt(name string) int {
 	return out
 }
 
+func (c *context) ParamInt64(name string) int64 {
+	out, _ := c.Param(name).(int64)
+	return out
+}
+
+func (c *context) ParamUint64(name string) uint64 {
+	out, _ := c.Param(name).(uint64)
+	return out
+}
+
+func (c *context) ParamBigInt(name string) *big.Int {
+	out, _ := c.Param(name).(*big.Int)
+	return out
+}
+
+func (c *context) ParamDecimal(name string) convert.Decimal {
+	out, _ := c.Param(name).(convert.Decimal)
+	return out
+}
+
+func (c *context) ParamTime(name string) time.Time {
+	switch v := c.Param(name).(type) {
+	case time.Time:
+		return v
+	case *timestamp.Timestamp:
+		return convert.TimestampToTime(v)
+	default:
+		return time.Time{}
+	}
+}
+
+func (c *context) ParamIdentity(name string) identity.Id {
+	out, _ := c.Param(name).(identity.Id)
+	return out
+}
+
 func (c *context) Set(key string, val interface{}) {
 	if c.store == nil {
 		c.store = make(InterfaceMap)
@@ -294,4 +373,4 @@ func getCaller() string {
 	functionSplit := strings.Split(frame.Function, ".")
 	function := functionSplit[len(functionSplit)-1]
 	return fmt.Sprintf("%s:%d->%s", file, frame.Line, function)
-}
\ No newline at end of file
+}
@@ -0,0 +1,94 @@
+package router
+
+import (
+	"reflect"
+)
+
+// DescribePos passed to MiddlewareFunc as pos when router collects method metadata,
+// only param middleware, created with ParamMiddleware, is called with DescribePos
+const DescribePos = -1
+
+const (
+	paramMetaKey = `_paramMeta`
+	paramErrKey  = `_paramErr`
+)
+
+// IsDescribe returns true if middleware is called for collecting method metadata
+func IsDescribe(pos ...int) bool {
+	return len(pos) > 0 && pos[0] == DescribePos
+}
+
+// ParamMiddleware creates middleware, converting stub args to method param. set converts arg to param
+// and records param error with SetParamError. When param error is recorded, other (not param) middleware
+// of method is skipped, so only param middleware collects errors before handler call
+func ParamMiddleware(meta ParamMeta, set func(c Context)) MiddlewareFunc {
+	return func(next HandlerFunc, pos ...int) HandlerFunc {
+		if IsDescribe(pos...) {
+			c := NewContext(nil, nil)
+			c.Set(paramMetaKey, meta)
+			_, _ = next(c)
+			return next
+		}
+		return func(c Context) (interface{}, error) {
+			set(c)
+			return next(c)
+		}
+	}
+}
+
+// paramMiddlewareCode code pointer of func literal, returned by ParamMiddleware.
+// All closures of one func literal share code pointer, so it marks param middleware
+var paramMiddlewareCode = reflect.ValueOf(ParamMiddleware(ParamMeta{}, nil)).Pointer()
+
+func isParamMiddleware(m MiddlewareFunc) bool {
+	return reflect.ValueOf(m).Pointer() == paramMiddlewareCode
+}
+
+func describeParams(middleware []MiddlewareFunc) []ParamMeta {
+	var params []ParamMeta
+	collect := func(c Context) (interface{}, error) {
+		if meta, ok := c.Get(paramMetaKey).(ParamMeta); ok {
+			params = append(params, meta)
+		}
+		return nil, nil
+	}
+
+	for _, m := range middleware {
+		if isParamMiddleware(m) {
+			m(collect, DescribePos)
+		}
+	}
+	return params
+}
+
+// SetParamError sets error of method params, handler is not called if param error is set.
+// Param middleware (router/param) accumulates violations of all params, so they are reported at once
+func SetParamError(c Context, err error) {
+	c.Set(paramErrKey, err)
+}
+
+// ParamError returns error of method params, set by param middleware
+func ParamError(c Context) error {
+	err, _ := c.Get(paramErrKey).(error)
+	return err
+}
+
+// skipOnParamError calls next instead of middleware handler h, if param error is recorded
+func skipOnParamError(h, next HandlerFunc) HandlerFunc {
+	return func(c Context) (interface{}, error) {
+		if ParamError(c) != nil {
+			return next(c)
+		}
+		return h(c)
+	}
+}
+
+// checkParams returns param error, accumulated by param middleware, instead of calling handler
+func checkParams(next HandlerFunc) HandlerFunc {
+	return func(c Context) (interface{}, error) {
+		if err := ParamError(c); err != nil {
+			return nil, err
+		}
+		return next(c)
+	}
+}
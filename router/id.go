@@ -0,0 +1,82 @@
+package router
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/rand"
+)
+
+// crockford base32 alphabet, used in ULID encoding
+const idEncoding = `0123456789ABCDEFGHJKMNPQRSTVWXYZ`
+
+// ErrTxIdEmpty occurs when deterministic value requested, but tx id is not set
+var ErrTxIdEmpty = errors.New(`tx id is empty`)
+
+// NewID returns unique within channel, ULID-like, lexicographically ordered id.
+// Id is built from tx timestamp (48 bit, milliseconds), per-tx counter (32 bit) and tx id hash (48 bit),
+// so all endorsers produce same ids for same tx
+func (c *context) NewID() (string, error) {
+	txTime, err := c.Time()
+	if err != nil {
+		return ``, err
+	}
+	seed, err := c.nextSeed()
+	if err != nil {
+		return ``, err
+	}
+
+	var id [16]byte
+	ms := uint64(txTime.UnixNano() / 1e6)
+	binary.BigEndian.PutUint64(id[0:8], ms<<16)        // 48 bit timestamp
+	binary.BigEndian.PutUint32(id[6:10], *c.idCounter) // 32 bit counter
+	copy(id[10:], seed[:6])                            // 48 bit tx id hash
+	return encodeID(id), nil
+}
+
+// DeterministicRand returns pseudo random generator, seeded with tx id and per-tx counter
+func (c *context) DeterministicRand() (*rand.Rand, error) {
+	seed, err := c.nextSeed()
+	if err != nil {
+		return nil, err
+	}
+	return rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(seed[:8])))), nil
+}
+
+// nextSeed increments per-tx counter and returns hash of tx id and counter
+func (c *context) nextSeed() ([sha256.Size]byte, error) {
+	txId := c.stub.GetTxID()
+	if txId == `` {
+		return [sha256.Size]byte{}, ErrTxIdEmpty
+	}
+	*c.counter()++
+
+	counter := make([]byte, 4)
+	binary.BigEndian.PutUint32(counter, *c.idCounter)
+	return sha256.Sum256(append([]byte(txId), counter...)), nil
+}
+
+func (c *context) counter() *uint32 {
+	if c.idCounter == nil {
+		c.idCounter = new(uint32)
+	}
+	return c.idCounter
+}
+
+// encodeID encodes 128 bit id as 26 chars crockford base32 string
+func encodeID(id [16]byte) string {
+	out := make([]byte, 26)
+	// 130 bits, first 2 bits are always zero
+	var acc uint32
+	bits, pos := 2, 0
+	for _, b := range id {
+		acc = acc<<8 | uint32(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			out[pos] = idEncoding[(acc>>uint(bits))&0x1f]
+			pos++
+		}
+	}
+	return string(out)
+}
@@ -0,0 +1,122 @@
+package router
+
+import (
+	"fmt"
+	"sort"
+
+	. "github.com/optherium/cckit/errors"
+	"github.com/optherium/cckit/state"
+)
+
+const (
+	// SchemaVersionStateKey state key of applied migrations version
+	SchemaVersionStateKey = `_SCHEMA_VERSION`
+
+	// QuerySchemaVersionMethod returns applied migrations version
+	QuerySchemaVersionMethod = `schemaVersion`
+
+	// initTxKey context store key, set by HandleInit, so migrations are not applied on invoke of init method
+	initTxKey = `_initTx`
+)
+
+var (
+	// ErrMigrationFailed occurs when migration returns error, init tx is aborted
+	ErrMigrationFailed = NewCCError(500, `MIGRATION_FAILED`, `migration failed`)
+)
+
+type (
+	// MigrationFunc changes state layout, invoked with init context
+	MigrationFunc func(Context) error
+
+	// Migrations registry of state migrations, applied on chaincode init in version order
+	Migrations struct {
+		items []*migration
+	}
+
+	migration struct {
+		version int
+		fn      MigrationFunc
+	}
+)
+
+// Migrations returns migrations registry, pending migrations are applied on chaincode init (HandleInit)
+// after init handler, invoke of init method doesn't apply migrations. Registry must be defined on root group
+func (g *Group) Migrations() *Migrations {
+	if g.migrations == nil {
+		g.migrations = &Migrations{}
+		g.Query(QuerySchemaVersionMethod, querySchemaVersion)
+	}
+	return g.migrations
+}
+
+// Add adds migration, version must be positive and unique
+func (m *Migrations) Add(version int, fn MigrationFunc) *Migrations {
+	if version <= 0 {
+		panic(fmt.Sprintf(`migration version must be positive: %d`, version))
+	}
+	for _, existing := range m.items {
+		if existing.version == version {
+			panic(fmt.Sprintf(`migration version already defined: %d`, version))
+		}
+	}
+
+	m.items = append(m.items, &migration{version: version, fn: fn})
+	sort.Slice(m.items, func(i, j int) bool { return m.items[i].version < m.items[j].version })
+	return m
+}
+
+// Version returns latest migration version
+func (m *Migrations) Version() int {
+	if len(m.items) == 0 {
+		return 0
+	}
+	return m.items[len(m.items)-1].version
+}
+
+// wrapInit applies pending migrations after successful init handler
+func (m *Migrations) wrapInit(next HandlerFunc) HandlerFunc {
+	return func(c Context) (interface{}, error) {
+		res, err := next(c)
+		if err != nil {
+			return nil, err
+		}
+		if err = m.apply(c); err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+}
+
+func (m *Migrations) apply(c Context) error {
+	current, err := SchemaVersion(c)
+	if err != nil {
+		return err
+	}
+
+	for _, mig := range m.items {
+		if mig.version <= current {
+			continue
+		}
+		c.Infof(`apply migration: %d`, mig.version)
+		if err = mig.fn(c); err != nil {
+			return ErrMigrationFailed.WithDetail(`version`, fmt.Sprint(mig.version)).Wrap(err)
+		}
+		current = mig.version
+	}
+
+	return rawState(c).Put(SchemaVersionStateKey, current)
+}
+
+// SchemaVersion returns applied migrations version, 0 if no migrations applied
+func SchemaVersion(c Context) (int, error) {
+	return rawState(c).GetInt(SchemaVersionStateKey, 0)
+}
+
+func querySchemaVersion(c Context) (interface{}, error) {
+	return SchemaVersion(c)
+}
+
+// rawState returns state without context state wrappers (mapping, encryption), schema version stored as is
+func rawState(c Context) state.State {
+	return state.NewState(c.Stub(), c.Logger())
+}
@@ -5,6 +5,6 @@ import (
 	"github.com/optherium/cckit/router/param"
 )
 
-func Proto(target interface{}, argPoss ...int) router.MiddlewareFunc {
-	return param.Proto(router.DefaultParam, target, argPoss...)
+func Proto(target interface{}, opts ...interface{}) router.MiddlewareFunc {
+	return param.Proto(router.DefaultParam, target, opts...)
 }
@@ -0,0 +1,221 @@
+package param_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/optherium/cckit/convert"
+	"github.com/optherium/cckit/examples/cpaper_extended/schema"
+	"github.com/optherium/cckit/identity"
+	"github.com/optherium/cckit/router"
+	p "github.com/optherium/cckit/router/param"
+	"github.com/optherium/cckit/router/param/defparam"
+	testcc "github.com/optherium/cckit/testing"
+	expectcc "github.com/optherium/cckit/testing/expect"
+)
+
+func TestParam(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Param suite")
+}
+
+type OrderType string
+
+const (
+	OrderTypeBuy  OrderType = `buy`
+	OrderTypeSell OrderType = `sell`
+)
+
+var r = router.New(`param`)
+
+func New() *router.Chaincode {
+	r.
+		Init(router.EmptyContextHandler).
+		Invoke(`validate`, func(c router.Context) (interface{}, error) {
+			return c.ParamString(`symbol`), nil
+		},
+			p.String(`symbol`, p.Required(), p.Length(3, 5), p.Regex(`^[A-Z]+$`)),
+			p.Int(`amount`, p.Min(1), p.Max(100)),
+			p.String(`kind`, p.OneOf(`buy`, `sell`))).
+		Invoke(`guarded`, func(c router.Context) (interface{}, error) {
+			return c.ParamString(`symbol`), nil
+		},
+			p.String(`symbol`, p.Length(3, 5)),
+			// middleware relies on converted param
+			func(next router.HandlerFunc, pos ...int) router.HandlerFunc {
+				return func(c router.Context) (interface{}, error) {
+					if len(c.Param(`symbol`).(string)) == 0 {
+						return nil, errors.New(`empty symbol`)
+					}
+					return next(c)
+				}
+			},
+			p.Int(`amount`, p.Max(100))).
+		Invoke(`proto`, func(c router.Context) (interface{}, error) {
+			return c.Param(), nil
+		}, defparam.Proto(&schema.IssueCommercialPaper{})).
+		Invoke(`types`, func(c router.Context) (interface{}, error) {
+			return []interface{}{
+				c.ParamInt64(`int64`),
+				c.ParamBigInt(`bigInt`).String(),
+				c.ParamDecimal(`decimal`).String(),
+				c.ParamTime(`time`).Unix(),
+				c.ParamIdentity(`identity`).MSP,
+				c.ParamString(`orderType`),
+			}, nil
+		},
+			p.Int64(`int64`), p.BigInt(`bigInt`), p.Decimal(`decimal`, 2, p.Min(0.01)),
+			p.Time(`time`), p.Identity(`identity`),
+			p.Enum(`orderType`, []OrderType{OrderTypeBuy, OrderTypeSell})).
+		Query(`list`, func(c router.Context) (interface{}, error) {
+			return []interface{}{c.ParamString(`prefix`), c.ParamInt(`limit`), c.Param(`desc`)}, nil
+		}, p.String(`prefix`), p.Int(`limit`, p.Default(10), p.Max(100)), p.Optional(`desc`, convert.TypeBool, false))
+
+	return router.NewChaincode(r)
+}
+
+var _ = Describe(`Param`, func() {
+
+	cc := testcc.NewMockStub(`param`, New())
+
+	Describe(`Validation`, func() {
+
+		It(`Allow valid params`, func() {
+			expectcc.PayloadString(cc.Invoke(`validate`, `ABC`, 10, `buy`), `ABC`)
+		})
+
+		It(`Disallow param with violations of several rules`, func() {
+			expectcc.ResponseError(cc.Invoke(`validate`, `abcdef`, 10, `buy`),
+				`param validation: symbol length: length must be between 3 and 5, got 6; symbol regex:`)
+		})
+
+		It(`Disallow numeric param out of range`, func() {
+			expectcc.ResponseError(cc.Invoke(`validate`, `ABC`, 101, `buy`),
+				`param validation: amount max: value must be less than or equal to 100`)
+		})
+
+		It(`Disallow param not in enum`, func() {
+			expectcc.ResponseError(cc.Invoke(`validate`, `ABC`, 1, `hold`),
+				`param validation: kind oneOf:`)
+		})
+
+		It(`Disallow params with violations, reported at once`, func() {
+			expectcc.ResponseError(cc.Invoke(`validate`, `abcdef`, 101, `hold`),
+				`param validation: symbol length: length must be between 3 and 5, got 6; symbol regex: value must match ^[A-Z]+$; `+
+					`amount max: value must be less than or equal to 100; kind oneOf:`)
+		})
+
+		It(`Disallow not finite float values`, func() {
+			parameter, err := p.NewParameter(`ratio`, float64(0), 0, p.Min(0), p.Max(1))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parameter.ArgPos).To(Equal(0))
+
+			for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+				Expect(parameter.Validate(v)).To(MatchError(ContainSubstring(p.ErrNotFinite.Error())))
+			}
+			Expect(parameter.Validate(0.5)).To(Succeed())
+		})
+
+		It(`Skip other middleware when params are not valid`, func() {
+			expectcc.PayloadString(cc.Invoke(`guarded`, `ABC`, 10), `ABC`)
+			expectcc.ResponseError(cc.Invoke(`guarded`, `abcdef`, 101),
+				`param validation: symbol length: length must be between 3 and 5, got 6; amount max:`)
+		})
+
+		It(`Disallow unknown param options`, func() {
+			_, err := p.NewParameter(`ratio`, float64(0), `min`)
+			Expect(err).To(MatchError(ContainSubstring(p.ErrUnknownParamOption.Error())))
+		})
+
+		It(`Disallow invalid proto payload`, func() {
+			expectcc.ResponseError(cc.Invoke(`proto`, &schema.IssueCommercialPaper{Issuer: `some-issuer`}),
+				`param validation: default proto: invalid field PaperNumber`)
+		})
+	})
+	Describe(`Types`, func() {
+
+		It(`Allow int64, big int, decimal, time, identity and enum params`, func() {
+			t := time.Unix(1556700000, 0)
+			res := expectcc.PayloadIs(cc.Invoke(`types`,
+				int64(1099511627776), `123456789012345678901234567890`, `10.5`,
+				t, identity.Id{MSP: `SOME_MSP`, Cert: `some-cert`}, OrderTypeSell), &[]interface{}{}).([]interface{})
+
+			Expect(res).To(Equal([]interface{}{
+				float64(1099511627776), `123456789012345678901234567890`, `10.50`,
+				float64(1556700000), `SOME_MSP`, `sell`}))
+		})
+
+		It(`Disallow decimal param with negative scale`, func() {
+			g := router.New(`decimal`).Invoke(`amount`, func(c router.Context) (interface{}, error) {
+				return c.ParamDecimal(`amount`).String(), nil
+			}, p.Decimal(`amount`, -1))
+			expectcc.ResponseError(testcc.NewMockStub(`decimal`, router.NewChaincode(g)).Invoke(`amount`, `100`),
+				convert.ErrDecimalNegativeScale)
+		})
+
+		It(`Disallow decimal with exceeded scale`, func() {
+			expectcc.ResponseError(cc.Invoke(`types`, 1, 1, `0.001`),
+				`param validation: decimal type: `+convert.ErrDecimalScaleExceeded.Error())
+		})
+
+		It(`Disallow unknown enum value`, func() {
+			expectcc.ResponseError(cc.Invoke(`types`, 1, 1, `1`, time.Now(), identity.Id{}, `hold`),
+				`param validation: orderType oneOf:`)
+		})
+	})
+	Describe(`Optional`, func() {
+
+		It(`Allow to omit optional trailing params`, func() {
+			Expect(expectcc.PayloadIs(cc.Query(`list`, `a`), &[]interface{}{})).To(
+				Equal([]interface{}{`a`, float64(10), false}))
+			Expect(expectcc.PayloadIs(cc.Query(`list`, `a`, 20), &[]interface{}{})).To(
+				Equal([]interface{}{`a`, float64(20), false}))
+			Expect(expectcc.PayloadIs(cc.Query(`list`, `a`, 20, true), &[]interface{}{})).To(
+				Equal([]interface{}{`a`, float64(20), true}))
+		})
+
+		It(`Disallow to omit required param`, func() {
+			expectcc.ResponseError(cc.Query(`list`),
+				`param validation: prefix required: param expected at pos 0, stub args length: 0`)
+		})
+
+		It(`Allow to get params metadata`, func() {
+			params := r.Handlers()[`list`].Params
+			Expect(params).To(HaveLen(3))
+			Expect(params[0]).To(Equal(router.ParamMeta{Name: `prefix`, Type: `string`, ArgPos: -1}))
+			Expect(params[1]).To(Equal(router.ParamMeta{
+				Name: `limit`, Type: `int`, ArgPos: -1, Optional: true, Default: 10, Rules: []string{p.RuleMax}}))
+			Expect(params[2].Optional).To(BeTrue())
+		})
+
+		It(`Allow to get params metadata without calling other middleware`, func() {
+			var calls int
+			counter := func(next router.HandlerFunc, pos ...int) router.HandlerFunc {
+				calls++
+				return next
+			}
+
+			g := router.New(`describe`).Query(`get`, router.EmptyContextHandler, counter, p.String(`id`))
+			Expect(calls).To(BeZero())
+			Expect(g.Handlers()[`get`].Params).To(HaveLen(1))
+
+			// metadata is copied
+			g.Handlers()[`get`].Params[0].Name = `changed`
+			delete(g.Handlers(), `get`)
+			Expect(g.Handlers()[`get`].Params[0].Name).To(Equal(`id`))
+		})
+
+		It(`Disallow default value of other type`, func() {
+			_, err := p.NewParameter(`limit`, convert.TypeInt, p.Default(int64(10)))
+			Expect(err).To(MatchError(ContainSubstring(p.ErrDefaultTypeMismatch.Error())))
+
+			_, err = p.NewParameter(`issue`, &schema.IssueCommercialPaper{}, p.Default(&schema.IssueCommercialPaper{}))
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
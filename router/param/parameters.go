@@ -2,6 +2,7 @@ package param
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/pkg/errors"
 	"github.com/optherium/cckit/convert"
@@ -10,8 +11,16 @@ import (
 
 const LastPosKey = `_lastPos`
 
-// ErrPayloadValidationError occurs when payload validation not passed
-var ErrPayloadValidationError = errors.New(`payload validation`)
+var (
+	// ErrPayloadValidationError occurs when payload validation not passed
+	ErrPayloadValidationError = errors.New(`payload validation`)
+
+	// ErrDefaultTypeMismatch occurs when type of param default value doesn't match param type
+	ErrDefaultTypeMismatch = errors.New(`default value type mismatch`)
+
+	// ErrUnknownParamOption occurs when param option has unsupported type
+	ErrUnknownParamOption = errors.New(`unknown param option`)
+)
 
 type (
 	// Parameters list of chain code function parameters
@@ -19,17 +28,55 @@ type (
 
 	// Parameter of chain code function
 	Parameter struct {
-		Name   string
-		Type   interface{}
-		ArgPos int
+		Name     string
+		Type     interface{}
+		ArgPos   int
+		Rules    []Rule
+		Optional bool
+		Default  interface{}
 	}
 
+	// Option modifies parameter definition
+	Option func(*Parameter)
+
 	//DefinedParams
 
 	// MiddlewareFuncMap named list of middleware functions
 	MiddlewareFuncMap map[string]router.MiddlewareFunc
 )
 
+// NewParameter creates parameter definition, opts can contain arg position (int) or Option
+func NewParameter(name string, paramType interface{}, opts ...interface{}) (Parameter, error) {
+	parameter := Parameter{Name: name, Type: paramType, ArgPos: -1} // by default use next pos
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case int:
+			parameter.ArgPos = o
+		case Option:
+			o(&parameter)
+		default:
+			return parameter, fmt.Errorf(`%s: %T`, ErrUnknownParamOption, opt)
+		}
+	}
+
+	if parameter.Optional && parameter.Default != nil && !defaultAssignable(parameter.Default, paramType) {
+		return parameter, fmt.Errorf(`%s: param %s, default %T, type %T`,
+			ErrDefaultTypeMismatch, name, parameter.Default, paramType)
+	}
+	return parameter, nil
+}
+
+// defaultAssignable checks default value has type of converted arg, for pointer types (i.e. proto) value
+// of pointed type is allowed too
+func defaultAssignable(defaultValue, paramType interface{}) bool {
+	if paramType == nil {
+		return true
+	}
+	dt, pt := reflect.TypeOf(defaultValue), reflect.TypeOf(paramType)
+	return dt.AssignableTo(pt) || (pt.Kind() == reflect.Ptr && dt.AssignableTo(pt.Elem()))
+}
+
 func (p Parameter) ValueFromContext(c router.Context) (arg interface{}, err error) {
 	// by default args start from pos 1 , at first pos is funcName
 	argsStartsFrom := 1
@@ -49,40 +96,106 @@ func (p Parameter) ValueFromContext(c router.Context) (arg interface{}, err erro
 
 	args := c.GetArgs()[argsStartsFrom:] // first arg is chaincode function name
 	if argPos >= len(args) {
-		return nil, fmt.Errorf(
-			`method "%s", param "%s" not exists, param expected at pos : %d, stub args length: %d`,
-			c.Path(), p.Name, argPos, len(args))
+		if p.Optional {
+			return p.Default, nil
+		}
+		return nil, &ValidationError{Violations: []Violation{{
+			Param:   p.Name,
+			Rule:    RuleRequired,
+			Message: fmt.Sprintf(`param expected at pos %d, stub args length: %d`, argPos, len(args)),
+		}}}
 	}
 
 	return convert.FromBytes(args[argPos], p.Type) //first arg is function name
 }
 
+// Meta returns parameter metadata for router introspection
+func (p Parameter) Meta() router.ParamMeta {
+	meta := router.ParamMeta{
+		Name:     p.Name,
+		Type:     fmt.Sprintf(`%T`, p.Type),
+		ArgPos:   p.ArgPos,
+		Optional: p.Optional,
+		Default:  p.Default,
+	}
+	for _, rule := range p.Rules {
+		meta.Rules = append(meta.Rules, rule.Name)
+	}
+	return meta
+}
+
+// Validate checks value against all parameter rules and returns ValidationError with every violation
+func (p Parameter) Validate(value interface{}) error {
+	var violations []Violation
+	for _, rule := range p.Rules {
+		if err := rule.Check(value); err != nil {
+			violations = append(violations, Violation{Param: p.Name, Rule: rule.Name, Message: err.Error()})
+		}
+	}
+
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// Optional creates middleware function for optional parameter, defaultValue is used if arg is absent
+func Optional(name string, paramType interface{}, defaultValue interface{}, opts ...interface{}) router.MiddlewareFunc {
+	return Param(name, paramType, append([]interface{}{Default(defaultValue)}, opts...)...)
+}
+
+// Default makes parameter optional, defaultValue is used if arg is absent
+func Default(defaultValue interface{}) Option {
+	return func(p *Parameter) {
+		p.Optional = true
+		p.Default = defaultValue
+	}
+}
+
 // Add middleware function
 func (pbag MiddlewareFuncMap) Add(name string, paramType interface{}) MiddlewareFuncMap {
 	pbag[name] = Param(name, paramType)
 	return pbag
 }
 
-// Param create middleware function for transforming stub arg to context arg
-func Param(name string, paramType interface{}, argPoss ...int) router.MiddlewareFunc {
-	var argPos int
-	if len(argPoss) == 0 {
-		argPos = -1 // use next pos
-	} else {
-		argPos = argPoss[0]
+// Param create middleware function for transforming stub arg to context arg,
+// opts can contain arg position (int) and validation rules (Option).
+// Violations of all method params are reported at once, before handler call
+func Param(name string, paramType interface{}, opts ...interface{}) router.MiddlewareFunc {
+	parameter, err := NewParameter(name, paramType, opts...)
+	if err != nil {
+		return TypeErrorMiddleware(name, err)
 	}
 
-	parameter := Parameter{name, paramType, argPos}
+	return router.ParamMiddleware(parameter.Meta(), func(c router.Context) {
+		arg, err := parameter.ValueFromContext(c)
+		if err == nil {
+			err = parameter.Validate(arg)
+		}
 
-	return func(next router.HandlerFunc, pos ...int) router.HandlerFunc {
-		return func(c router.Context) (interface{}, error) {
+		if err != nil {
+			addParamError(c, parameter.Name, err)
+		} else {
+			c.SetParam(parameter.Name, arg)
+		}
+	})
+}
 
-			arg, err := parameter.ValueFromContext(c)
-			if err != nil {
-				return nil, err
-			}
-			c.SetParam(name, arg)
-			return next(c)
+// addParamError merges param error into ValidationError of method params, stored in context
+func addParamError(c router.Context, name string, err error) {
+	verr, ok := router.ParamError(c).(*ValidationError)
+	if !ok {
+		if router.ParamError(c) != nil {
+			return
 		}
+		verr = &ValidationError{}
+	}
+
+	if v, ok := err.(*ValidationError); ok {
+		verr.Violations = append(verr.Violations, v.Violations...)
+	} else {
+		// arg can't be converted to param type
+		verr.Violations = append(verr.Violations, Violation{Param: name, Rule: RuleType, Message: err.Error()})
 	}
+	router.SetParamError(c, verr)
 }
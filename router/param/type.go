@@ -2,52 +2,112 @@ package param
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/pkg/errors"
 	"github.com/optherium/cckit/convert"
+	"github.com/optherium/cckit/identity"
 	"github.com/optherium/cckit/router"
 )
 
 var (
 	ErrProtoExpected = errors.New(`protobuf expected`)
+	ErrEnumExpected  = errors.New(`slice of string based values expected`)
 )
 
 // String creates middleware for converting to string chaincode method parameter
-func String(name string, argPoss ...int) router.MiddlewareFunc {
-	return Param(name, convert.TypeString, argPoss...)
+func String(name string, opts ...interface{}) router.MiddlewareFunc {
+	return Param(name, convert.TypeString, opts...)
 }
 
-func Strings(name string, argPoss ...int) router.MiddlewareFunc {
-	return Param(name, []string{}, argPoss...)
+func Strings(name string, opts ...interface{}) router.MiddlewareFunc {
+	return Param(name, []string{}, opts...)
 }
 
 // Int creates middleware for converting to integer chaincode method parameter
-func Int(name string, argPoss ...int) router.MiddlewareFunc {
-	return Param(name, convert.TypeInt, argPoss...)
+func Int(name string, opts ...interface{}) router.MiddlewareFunc {
+	return Param(name, convert.TypeInt, opts...)
+}
+
+// Int64 creates middleware for converting to int64 chaincode method parameter
+func Int64(name string, opts ...interface{}) router.MiddlewareFunc {
+	return Param(name, convert.TypeInt64, opts...)
+}
+
+// Uint64 creates middleware for converting to uint64 chaincode method parameter
+func Uint64(name string, opts ...interface{}) router.MiddlewareFunc {
+	return Param(name, convert.TypeUint64, opts...)
+}
+
+// BigInt creates middleware for converting to arbitrary-precision *big.Int chaincode method parameter
+func BigInt(name string, opts ...interface{}) router.MiddlewareFunc {
+	return Param(name, convert.TypeBigInt, opts...)
+}
+
+// Decimal creates middleware for converting to fixed-point convert.Decimal chaincode method parameter,
+// argument must not contain more fractional digits than scale, scale must not be negative
+func Decimal(name string, scale int, opts ...interface{}) router.MiddlewareFunc {
+	if scale < 0 {
+		return TypeErrorMiddleware(name, convert.ErrDecimalNegativeScale)
+	}
+	return Param(name, convert.Decimal{Scale: scale}, opts...)
+}
+
+// Time creates middleware for converting RFC 3339 string to time.Time chaincode method parameter
+func Time(name string, opts ...interface{}) router.MiddlewareFunc {
+	return Param(name, convert.TypeTime, opts...)
+}
+
+// Timestamp creates middleware for converting to protobuf *timestamp.Timestamp chaincode method parameter
+func Timestamp(name string, opts ...interface{}) router.MiddlewareFunc {
+	return Param(name, &timestamp.Timestamp{}, opts...)
+}
+
+// Identity creates middleware for converting to identity.Id chaincode method parameter
+func Identity(name string, opts ...interface{}) router.MiddlewareFunc {
+	return Param(name, identity.Id{}, opts...)
+}
+
+// Enum creates middleware for converting to string based type chaincode method parameter,
+// allowed is slice of allowed values, i.e. []OrderType{OrderTypeBuy, OrderTypeSell}
+func Enum(name string, allowed interface{}, opts ...interface{}) router.MiddlewareFunc {
+	values := reflect.ValueOf(allowed)
+	if values.Kind() != reflect.Slice || values.Type().Elem().Kind() != reflect.String {
+		return TypeErrorMiddleware(name, ErrEnumExpected)
+	}
+
+	var oneOf []interface{}
+	for i := 0; i < values.Len(); i++ {
+		oneOf = append(oneOf, values.Index(i).Interface())
+	}
+
+	return Param(name, reflect.Zero(values.Type().Elem()).Interface(), append(opts[:len(opts):len(opts)], OneOf(oneOf...))...)
 }
 
 // Bool creates middleware for converting to bool chaincode method parameter
-func Bool(name string, argPoss ...int) router.MiddlewareFunc {
-	return Param(name, convert.TypeBool, argPoss...)
+func Bool(name string, opts ...interface{}) router.MiddlewareFunc {
+	return Param(name, convert.TypeBool, opts...)
 }
 
 // Struct creates middleware for converting to struct chaincode method parameter
-func Struct(name string, target interface{}, argPoss ...int) router.MiddlewareFunc {
-	return Param(name, target, argPoss...)
+func Struct(name string, target interface{}, opts ...interface{}) router.MiddlewareFunc {
+	return Param(name, target, opts...)
 }
 
 // Bytes creates middleware for converting to []byte chaincode method parameter
-func Bytes(name string, argPoss ...int) router.MiddlewareFunc {
-	return Param(name, []byte{}, argPoss...)
+func Bytes(name string, opts ...interface{}) router.MiddlewareFunc {
+	return Param(name, []byte{}, opts...)
 }
 
-// Proto creates middleware for converting to protobuf chaincode method parameter
-func Proto(name string, target interface{}, argPoss ...int) router.MiddlewareFunc {
+// Proto creates middleware for converting to protobuf chaincode method parameter,
+// if protobuf message implements Validate() method it will be called automatically
+func Proto(name string, target interface{}, opts ...interface{}) router.MiddlewareFunc {
 	if _, ok := target.(proto.Message); !ok {
-		TypeErrorMiddleware(name, ErrProtoExpected)
+		return TypeErrorMiddleware(name, ErrProtoExpected)
 	}
-	return Param(name, target, argPoss...)
+	return Param(name, target, append(opts[:len(opts):len(opts)], WithRule(RuleProto, validateProto))...)
 }
 
 func TypeErrorMiddleware(name string, err error) router.MiddlewareFunc {
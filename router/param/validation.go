@@ -0,0 +1,260 @@
+package param
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/optherium/cckit/convert"
+	"github.com/pkg/errors"
+)
+
+const (
+	RuleRequired = `required`
+	RuleType     = `type`
+	RuleMin      = `min`
+	RuleMax      = `max`
+	RuleLength   = `length`
+	RuleRegex    = `regex`
+	RuleOneOf    = `oneOf`
+	RuleProto    = `proto`
+)
+
+var (
+	// ErrParamValidation occurs when param value violates one or more validation rules
+	ErrParamValidation = errors.New(`param validation`)
+
+	// ErrRuleNotApplicable occurs when validation rule cannot be applied to param value type
+	ErrRuleNotApplicable = errors.New(`rule not applicable to value type`)
+
+	// ErrNotFinite occurs when float param value is NaN or infinity
+	ErrNotFinite = errors.New(`value must be finite number`)
+
+	// ErrBoundNotFinite occurs when min or max rule bound is NaN or infinity
+	ErrBoundNotFinite = errors.New(`rule bound must be finite number`)
+)
+
+type (
+	// Validator checks param value, returns error if value is invalid
+	Validator func(value interface{}) error
+
+	// Rule named validator of param value
+	Rule struct {
+		Name  string
+		Check Validator
+	}
+
+	// Violation of param validation rule
+	Violation struct {
+		Param   string `json:"param"`
+		Rule    string `json:"rule"`
+		Message string `json:"message"`
+	}
+
+	// ValidationError contains all violations of param validation rules
+	ValidationError struct {
+		Violations []Violation
+	}
+
+	// ProtoValidator interface implemented by protobuf messages generated with validators plugin
+	ProtoValidator interface {
+		Validate() error
+	}
+)
+
+func (e *ValidationError) Error() string {
+	var vv []string
+	for _, v := range e.Violations {
+		vv = append(vv, fmt.Sprintf(`%s %s: %s`, v.Param, v.Rule, v.Message))
+	}
+	return fmt.Sprintf(`%s: %s`, ErrParamValidation, strings.Join(vv, `; `))
+}
+
+// Cause returns ErrParamValidation, so errors.Cause can be used for error identification
+func (e *ValidationError) Cause() error {
+	return ErrParamValidation
+}
+
+// WithRule adds validation rule to parameter
+func WithRule(name string, check Validator) Option {
+	return func(p *Parameter) {
+		p.Rules = append(p.Rules, Rule{Name: name, Check: check})
+	}
+}
+
+// Custom adds custom validator func to parameter
+func Custom(name string, check Validator) Option {
+	return WithRule(name, check)
+}
+
+// Required checks param value is not zero value (empty string, empty slice, nil pointer etc)
+func Required() Option {
+	return WithRule(RuleRequired, func(value interface{}) error {
+		if value == nil {
+			return errors.New(`value required`)
+		}
+		v := reflect.ValueOf(value)
+		switch v.Kind() {
+		case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+			if v.Len() == 0 {
+				return errors.New(`value required`)
+			}
+		case reflect.Ptr, reflect.Interface:
+			if v.IsNil() {
+				return errors.New(`value required`)
+			}
+		}
+		return nil
+	})
+}
+
+// Min checks numeric param value is greater than or equal to min
+func Min(min float64) Option {
+	bound := new(big.Rat).SetFloat64(min)
+	return WithRule(RuleMin, func(value interface{}) error {
+		if bound == nil {
+			return fmt.Errorf(`%s: %v`, ErrBoundNotFinite, min)
+		}
+		r, err := toRat(value)
+		if err != nil {
+			return err
+		}
+		if r.Cmp(bound) < 0 {
+			return fmt.Errorf(`value must be greater than or equal to %v`, min)
+		}
+		return nil
+	})
+}
+
+// Max checks numeric param value is less than or equal to max
+func Max(max float64) Option {
+	bound := new(big.Rat).SetFloat64(max)
+	return WithRule(RuleMax, func(value interface{}) error {
+		if bound == nil {
+			return fmt.Errorf(`%s: %v`, ErrBoundNotFinite, max)
+		}
+		r, err := toRat(value)
+		if err != nil {
+			return err
+		}
+		if r.Cmp(bound) > 0 {
+			return fmt.Errorf(`value must be less than or equal to %v`, max)
+		}
+		return nil
+	})
+}
+
+// Length checks length of string (in runes), bytes or slice param value, max = 0 means no upper limit
+func Length(min, max int) Option {
+	return WithRule(RuleLength, func(value interface{}) error {
+		var l int
+		switch v := value.(type) {
+		case string:
+			l = utf8.RuneCountInString(v)
+		default:
+			rv := reflect.ValueOf(value)
+			switch rv.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				l = rv.Len()
+			case reflect.String:
+				l = utf8.RuneCountInString(rv.String())
+			default:
+				return fmt.Errorf(`%s: %T`, ErrRuleNotApplicable, value)
+			}
+		}
+
+		if l < min || (max > 0 && l > max) {
+			if max > 0 {
+				return fmt.Errorf(`length must be between %d and %d, got %d`, min, max, l)
+			}
+			return fmt.Errorf(`length must be at least %d, got %d`, min, l)
+		}
+		return nil
+	})
+}
+
+// Regex checks string param value matches pattern, panics if pattern is invalid
+func Regex(pattern string) Option {
+	re := regexp.MustCompile(pattern)
+	return WithRule(RuleRegex, func(value interface{}) error {
+		var s string
+		switch v := value.(type) {
+		case string:
+			s = v
+		case []byte:
+			s = string(v)
+		default:
+			rv := reflect.ValueOf(value)
+			if rv.Kind() != reflect.String {
+				return fmt.Errorf(`%s: %T`, ErrRuleNotApplicable, value)
+			}
+			s = rv.String()
+		}
+
+		if !re.MatchString(s) {
+			return fmt.Errorf(`value must match %s`, pattern)
+		}
+		return nil
+	})
+}
+
+// OneOf checks param value equals to one of allowed values
+func OneOf(allowed ...interface{}) Option {
+	return WithRule(RuleOneOf, func(value interface{}) error {
+		for _, a := range allowed {
+			if reflect.DeepEqual(a, value) {
+				return nil
+			}
+		}
+		return fmt.Errorf(`value must be one of %v`, allowed)
+	})
+}
+
+// validateProto calls Validate method if value implements ProtoValidator interface
+func validateProto(value interface{}) error {
+	if v, ok := value.(ProtoValidator); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+func toRat(value interface{}) (*big.Rat, error) {
+	switch v := value.(type) {
+	case int:
+		return new(big.Rat).SetInt64(int64(v)), nil
+	case int32:
+		return new(big.Rat).SetInt64(int64(v)), nil
+	case int64:
+		return new(big.Rat).SetInt64(v), nil
+	case uint:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(uint64(v))), nil
+	case uint32:
+		return new(big.Rat).SetInt64(int64(v)), nil
+	case uint64:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(v)), nil
+	case float32:
+		return floatToRat(float64(v))
+	case float64:
+		return floatToRat(v)
+	case *big.Int:
+		return new(big.Rat).SetInt(v), nil
+	case *big.Rat:
+		return v, nil
+	case convert.Decimal:
+		return v.Rat(), nil
+	default:
+		return nil, fmt.Errorf(`%s: %T`, ErrRuleNotApplicable, value)
+	}
+}
+
+// floatToRat rejects NaN and infinity, not representable as big.Rat
+func floatToRat(v float64) (*big.Rat, error) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil, ErrNotFinite
+	}
+	return new(big.Rat).SetFloat64(v), nil
+}
@@ -0,0 +1,58 @@
+package router
+
+import (
+	"runtime/debug"
+
+	"github.com/hyperledger/fabric/protos/peer"
+	. "github.com/optherium/cckit/errors"
+)
+
+// RecoverContext creates pre middleware, converting panic in handlers chain
+// to error response with provided status and sanitized message
+func RecoverContext(status int32) ContextMiddlewareFunc {
+	return func(next ContextHandlerFunc, pos ...int) ContextHandlerFunc {
+		return func(c Context) (res peer.Response) {
+			defer func() {
+				if r := recover(); r != nil {
+					logPanic(c, r)
+					res = peer.Response{Status: status, Message: ErrHandlerPanic.Error()}
+				}
+			}()
+			return next(c)
+		}
+	}
+}
+
+// Recover middleware converts panic in handler and middleware chain to ErrHandlerPanic error
+func Recover(next HandlerFunc, pos ...int) HandlerFunc {
+	return func(c Context) (res interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logPanic(c, r)
+				res, err = nil, ErrHandlerPanic
+			}
+		}()
+		return next(c)
+	}
+}
+
+// RecoverStatus sets response status for panic recovered in handlers chain
+func (g *Group) RecoverStatus(status int32) *Group {
+	g.recoverStatus = status
+	return g
+}
+
+func (g *Group) recoverContext(next ContextHandlerFunc, pos ...int) ContextHandlerFunc {
+	return func(c Context) peer.Response {
+		return RecoverContext(g.recoverStatus)(next, pos...)(c)
+	}
+}
+
+func logPanic(c Context, r interface{}) {
+	var txID string
+	if c.Stub() != nil {
+		txID = c.Stub().GetTxID()
+	}
+	c.Logger().Errorf(`%s: tx %s: %s: %v
+%s`, ErrHandlerPanic, txID, c.Path(), r, debug.Stack())
+}
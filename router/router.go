@@ -33,8 +33,20 @@ type (
 	MiddlewareFunc func(HandlerFunc, ...int) HandlerFunc
 
 	HandlerMeta struct {
-		Hdl  HandlerFunc
-		Type MethodType
+		Hdl    HandlerFunc
+		Type   MethodType
+		Path   string
+		Params []ParamMeta
+	}
+
+	// ParamMeta describes chaincode method parameter, declared via middleware (i.e. router/param)
+	ParamMeta struct {
+		Name     string
+		Type     string
+		ArgPos   int
+		Optional bool
+		Default  interface{}
+		Rules    []string
 	}
 
 	// Group of chain code functions
@@ -54,6 +66,12 @@ type (
 		afterMiddleware []MiddlewareFunc
 
 		errs map[error]int32
+
+		// response status for panic recovered in handlers chain
+		recoverStatus int32
+
+		// state migrations, applied on init
+		migrations *Migrations
 	}
 
 	Router interface {
@@ -87,8 +105,12 @@ func (g *Group) HandleInit(stub shim.ChaincodeStubInterface) peer.Response {
 	// Pre context handling middleware
 	h := g.buildHandler()
 
+	c := g.Context(stub)
+	// migrations are applied only on chaincode init
+	c.Set(initTxKey, true)
+
 	// add "init" as first arg
-	return h(g.Context(stub).ReplaceArgs(append([][]byte{[]byte(InitFunc)}, stub.GetArgs()...)))
+	return h(c.ReplaceArgs(append([][]byte{[]byte(InitFunc)}, stub.GetArgs()...)))
 }
 
 // Handle used for using in CC Invoke function
@@ -128,6 +150,9 @@ func (g *Group) handleContext(c Context) peer.Response {
 
 			c.SetHandler(handlerMeta)
 			h := handlerMeta.Hdl
+			if handlerMeta.Path == InitFunc && g.migrations != nil && c.Get(initTxKey) == true {
+				h = g.migrations.wrapInit(h)
+			}
 			for i := len(g.middleware) - 1; i >= 0; i-- {
 				h = g.middleware[i](h, i)
 			}
@@ -143,7 +168,7 @@ func (g *Group) handleContext(c Context) peer.Response {
 		resp := response.Create(data, err)
 		if resp.Status != shim.OK {
 			g.logger.Errorf(`%s: %s: %s`, ErrHandlerError, c.Path(), resp.Message)
-			resp.Status = g.getErrorCode(err)
+			resp = g.errorResponse(err, resp)
 		}
 		return resp
 	}
@@ -153,12 +178,29 @@ func (g *Group) handleContext(c Context) peer.Response {
 	return shim.Error(err.Error())
 }
 
-func (g *Group) getErrorCode(err error) int32 {
-	if val, ok := g.errs[err]; ok {
-		return val
+// errorResponse sets error response status matched through err chain and serializes error details to payload
+func (g *Group) errorResponse(err error, resp peer.Response) peer.Response {
+	code, reason := g.getErrorCode(err)
+	resp.Status = code
+
+	if err != nil {
+		if payload, e := ToCCError(err, code, reason).Bytes(); e == nil {
+			resp.Payload = payload
+		}
+	}
+	return resp
+}
+
+func (g *Group) getErrorCode(err error) (int32, string) {
+	if err == ErrHandlerPanic {
+		return g.recoverStatus, ErrHandlerPanic.Error()
 	}
 
-	return shim.ERROR
+	if code, reason, ok := Code(err, g.errs); ok {
+		return code, reason
+	}
+
+	return shim.ERROR, ``
 }
 
 func (g *Group) Pre(middleware ...ContextMiddlewareFunc) *Group {
@@ -187,6 +229,8 @@ func (g *Group) Group(path string) *Group {
 		contextHandlers: g.contextHandlers,
 		handlers:        g.handlers,
 		middleware:      g.middleware,
+		recoverStatus:   g.recoverStatus,
+		migrations:      g.migrations,
 	}
 }
 
@@ -214,17 +258,34 @@ func (g *Group) Invoke(path string, handler HandlerFunc, middleware ...Middlewar
 
 func (g *Group) addHandler(t MethodType, path string, handler HandlerFunc, middleware ...MiddlewareFunc) *Group {
 	g.handlers[g.prefix+path] = &HandlerMeta{
-		Type: t,
+		Type:   t,
+		Path:   g.prefix + path,
+		Params: describeParams(middleware),
 		Hdl: func(context Context) (interface{}, error) {
-			h := handler
+			h := checkParams(handler)
 			for i := len(middleware) - 1; i >= 0; i-- {
-				h = middleware[i](h, i)
+				next := h
+				h = middleware[i](next, i)
+				if !isParamMiddleware(middleware[i]) {
+					h = skipOnParamError(h, next)
+				}
 			}
 			return h(context)
 		}}
 	return g
 }
 
+// Handlers returns copy of metadata of all registered handlers
+func (g *Group) Handlers() map[string]*HandlerMeta {
+	handlers := make(map[string]*HandlerMeta, len(g.handlers))
+	for path, h := range g.handlers {
+		meta := *h
+		meta.Params = append([]ParamMeta(nil), h.Params...)
+		handlers[path] = &meta
+	}
+	return handlers
+}
+
 func (g *Group) Init(handler HandlerFunc, middleware ...MiddlewareFunc) *Group {
 	return g.Invoke(InitFunc, handler, middleware...)
 }
@@ -234,24 +295,24 @@ func (g *Group) Context(stub shim.ChaincodeStubInterface) Context {
 	return NewContext(stub, g.logger)
 }
 
-// New group of chain code functions
+// New group of chain code functions, panics in handlers chain are recovered
 func New(name string) *Group {
 	g := new(Group)
 	g.logger = NewLogger(name)
 	g.stubHandlers = make(map[string]StubHandlerFunc)
 	g.contextHandlers = make(map[string]ContextHandlerFunc)
 	g.handlers = make(map[string]*HandlerMeta)
+	g.recoverStatus = shim.ERROR
+
+	g.Pre(g.recoverContext)
+	g.Use(Recover)
 
 	return g
 }
 
 // NewWithErrorMappings - new group of chain code functions with error mappings
 func NewWithErrorMappings(name string, errs map[error]int32) *Group {
-	g := new(Group)
-	g.logger = NewLogger(name)
-	g.stubHandlers = make(map[string]StubHandlerFunc)
-	g.contextHandlers = make(map[string]ContextHandlerFunc)
-	g.handlers = make(map[string]*HandlerMeta)
+	g := New(name)
 	g.errs = errs
 
 	return g
@@ -1,17 +1,26 @@
 package router_test
 
 import (
+	stderrors "errors"
+	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 
 	"github.com/hyperledger/fabric/protos/peer"
 
+	pkgerrors "github.com/pkg/errors"
+
+	"github.com/optherium/cckit/convert"
+	"github.com/optherium/cckit/errors"
 	testcc "github.com/optherium/cckit/testing"
+	expectcc "github.com/optherium/cckit/testing/expect"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/optherium/cckit/router"
+	"github.com/optherium/cckit/router/param"
 )
 
 func TestRouter(t *testing.T) {
@@ -24,17 +33,127 @@ func New() *router.Chaincode {
 		Init(router.EmptyContextHandler).
 		Invoke(`empty`, func(c router.Context) (interface{}, error) {
 			return nil, nil
+		}).
+		Invoke(`panic`, func(c router.Context) (interface{}, error) {
+			panic(`something went wrong`)
+		}).
+		ContextHandler(`panicContext`, func(c router.Context) peer.Response {
+			panic(`something went wrong`)
+		})
+
+	return router.NewChaincode(r)
+}
+
+var ErrInsufficientFunds = errors.NewCCError(409, `INSUFFICIENT_FUNDS`, `insufficient funds`)
+
+func NewWithErrors() *router.Chaincode {
+	r := router.NewWithErrorMappings(`router`, errors.GetErrorMappings()).
+		Init(router.EmptyContextHandler).
+		Invoke(`wrapped`, func(c router.Context) (interface{}, error) {
+			return nil, pkgerrors.Wrap(errors.ErrKeyNotFound, `get car`)
+		}).
+		Invoke(`formatted`, func(c router.Context) (interface{}, error) {
+			return nil, fmt.Errorf(`%s: %s`, errors.ErrKeyNotFound, `car`)
+		}).
+		Invoke(`structured`, func(c router.Context) (interface{}, error) {
+			return nil, pkgerrors.Wrap(ErrInsufficientFunds.WithDetail(`balance`, `10`), `transfer`)
+		})
+
+	return router.NewChaincode(r)
+}
+
+func NewWithBatch() *router.Chaincode {
+	r := router.New(`batch`).
+		Pre(func(next router.ContextHandlerFunc, pos ...int) router.ContextHandlerFunc {
+			return func(c router.Context) peer.Response {
+				c.Set(`prePath`, c.Path())
+				return next(c)
+			}
+		}).
+		Batch().
+		Init(router.EmptyContextHandler).
+		Invoke(`put`, func(c router.Context) (interface{}, error) {
+			if err := c.State().Put([]string{`item`, c.ParamString(`key`)}, c.ParamString(`value`)); err != nil {
+				return nil, err
+			}
+			return c.ParamString(`value`), c.Event().Set(`put`, c.ParamString(`key`))
+		}, param.String(`key`), param.String(`value`)).
+		Query(`get`, func(c router.Context) (interface{}, error) {
+			return c.State().Get([]string{`item`, c.ParamString(`key`)}, convert.TypeString)
+		}, param.String(`key`)).
+		Query(`list`, func(c router.Context) (interface{}, error) {
+			return c.State().List(`item`, convert.TypeString)
+		}).
+		Invoke(`copy`, func(c router.Context) (interface{}, error) {
+			value, err := c.State().Get([]string{`item`, c.ParamString(`from`)}, convert.TypeString)
+			if err != nil {
+				return nil, err
+			}
+			return value, c.State().Put([]string{`item`, c.ParamString(`to`)}, value)
+		}, param.String(`from`), param.String(`to`)).
+		Invoke(`putPrivate`, func(c router.Context) (interface{}, error) {
+			return nil, c.Stub().PutPrivateData(`collection`, c.ParamString(`key`), []byte(c.ParamString(`value`)))
+		}, param.String(`key`), param.String(`value`)).
+		Invoke(`getPrivate`, func(c router.Context) (interface{}, error) {
+			return c.Stub().GetPrivateData(`collection`, c.ParamString(`key`))
+		}, param.String(`key`)).
+		Invoke(`history`, func(c router.Context) (interface{}, error) {
+			return c.Stub().GetHistoryForKey(c.ParamString(`key`))
+		}, param.String(`key`)).
+		Invoke(`prePath`, func(c router.Context) (interface{}, error) {
+			return c.Get(`prePath`), nil
+		}).
+		Invoke(`fail`, func(c router.Context) (interface{}, error) {
+			return nil, ErrInsufficientFunds
+		})
+
+	return router.NewChaincode(r)
+}
+
+// NewWithMigrations creates chaincode with migrations up to version
+func NewWithMigrations(version int) *router.Chaincode {
+	r := router.New(`migrations`).
+		Init(router.EmptyContextHandler)
+
+	// count applies of each migration
+	countApplied := func(v int) router.MigrationFunc {
+		return func(c router.Context) error {
+			key := []string{`applied`, strconv.Itoa(v)}
+			applied, err := c.State().GetInt(key, 0)
+			if err != nil {
+				return err
+			}
+			return c.State().Put(key, applied+1)
+		}
+	}
+
+	for v := 1; v <= version; v++ {
+		r.Migrations().Add(v, countApplied(v))
+	}
+	if version < 0 {
+		r.Migrations().Add(1, func(c router.Context) error {
+			return ErrInsufficientFunds
 		})
+	}
 
 	return router.NewChaincode(r)
 }
 
-var cc *testcc.MockStub
+func batchCall(path string, args ...string) router.BatchCall {
+	call := router.BatchCall{Path: path}
+	for _, arg := range args {
+		call.Args = append(call.Args, []byte(arg))
+	}
+	return call
+}
+
+var cc, ccWithErrors, ccWithBatch *testcc.MockStub
 
 var _ = Describe(`Router`, func() {
 
 	BeforeSuite(func() {
 		cc = testcc.NewMockStub(`Router`, New())
+		ccWithErrors = testcc.NewMockStub(`Router`, NewWithErrors())
 	})
 
 	It(`Allow empty response`, func() {
@@ -46,4 +165,176 @@ var _ = Describe(`Router`, func() {
 		}))
 	})
 
+	It(`Recover panic in handler`, func() {
+		expectcc.ResponseErrorWithCode(cc.Invoke(`panic`), errors.ErrHandlerPanic, shim.ERROR)
+	})
+
+	It(`Recover panic in context handler`, func() {
+		expectcc.ResponseErrorWithCode(cc.Invoke(`panicContext`), errors.ErrHandlerPanic, shim.ERROR)
+	})
+
+	It(`Match error code through wrapped error`, func() {
+		expectcc.ResponseErrorWithCode(ccWithErrors.Invoke(`wrapped`), `get car: `+errors.ErrKeyNotFound.Error(), 402)
+	})
+
+	It(`Disallow to match error code by formatted error message`, func() {
+		// only errors in Unwrap / Cause chain are matched
+		expectcc.ResponseErrorWithCode(ccWithErrors.Invoke(`formatted`), errors.ErrKeyNotFound, shim.ERROR)
+	})
+
+	It(`Serialize structured error details to response payload`, func() {
+		res := ccWithErrors.Invoke(`structured`)
+		Expect(res.Status).To(BeEquivalentTo(409))
+
+		ccErr := expectcc.ResponseErrorIs(res, ErrInsufficientFunds)
+		Expect(ccErr.Reason).To(Equal(`INSUFFICIENT_FUNDS`))
+		Expect(ccErr.Details).To(Equal(map[string]string{`balance`: `10`}))
+		Expect(stderrors.Is(ccErr, ErrInsufficientFunds)).To(BeTrue())
+	})
+
+	It(`Generate deterministic ordered ids within tx`, func() {
+		newIds := func(txId string) (ids []string, random int64) {
+			stub := shim.NewMockStub(`ids`, nil)
+			stub.MockTransactionStart(txId)
+			c := router.NewContext(stub, nil)
+
+			for i := 0; i < 3; i++ {
+				id, err := c.NewID()
+				Expect(err).NotTo(HaveOccurred())
+				ids = append(ids, id)
+			}
+			rnd, err := c.DeterministicRand()
+			Expect(err).NotTo(HaveOccurred())
+			return ids, rnd.Int63()
+		}
+
+		ids1, random1 := newIds(`tx1`)
+		ids2, random2 := newIds(`tx1`)
+		Expect(ids1[0] < ids1[1] && ids1[1] < ids1[2]).To(BeTrue())
+		// timestamp part (first 10 chars) depends on mock stub tx time
+		Expect(ids1[1][10:]).To(Equal(ids2[1][10:]))
+		Expect(random1).To(Equal(random2))
+
+		ids3, random3 := newIds(`tx2`)
+		Expect(ids3[0][16:]).NotTo(Equal(ids1[0][16:]))
+		Expect(random3).NotTo(Equal(random1))
+	})
+
+	Describe(`Batch`, func() {
+
+		BeforeEach(func() {
+			ccWithBatch = testcc.NewMockStub(`batch`, NewWithBatch())
+		})
+
+		It(`Allow to invoke several methods atomically with shared state`, func() {
+			events := ccWithBatch.EventSubscription()
+			responses := expectcc.PayloadIs(ccWithBatch.Invoke(router.BatchMethod, []router.BatchCall{
+				batchCall(`put`, `item1`, `a`),
+				batchCall(`put`, `item2`, `b`),
+				batchCall(`copy`, `item1`, `item3`),
+			}), &[]peer.Response{}).([]peer.Response)
+
+			Expect(responses).To(HaveLen(3))
+			Expect(string(responses[0].Payload)).To(Equal(`a`))
+			// state changes of previous calls are visible
+			Expect(string(responses[2].Payload)).To(Equal(`a`))
+
+			Expect(ccWithBatch.Query(`get`, `item2`).Payload).To(Equal([]byte(`b`)))
+			Expect(ccWithBatch.Query(`list`).Payload).To(Equal([]byte(`["a","b","a"]`)))
+
+			batchEvents := expectcc.EventPayloadIs(<-events, &[]router.BatchEvent{}).([]router.BatchEvent)
+			Expect(batchEvents).To(HaveLen(2))
+			Expect(batchEvents[1].Call).To(Equal(1))
+			Expect(batchEvents[1].Name).To(Equal(`put`))
+			Expect(string(batchEvents[1].Payload)).To(Equal(`item2`))
+		})
+
+		It(`Abort batch if any call fails`, func() {
+			ccErr := expectcc.ResponseErrorIs(ccWithBatch.Invoke(router.BatchMethod, []router.BatchCall{
+				batchCall(`put`, `item1`, `a`),
+				batchCall(`fail`),
+			}), ErrInsufficientFunds)
+
+			Expect(ccErr.Details[`batchCall`]).To(Equal(`1`))
+			Expect(ccErr.Details[`batchPath`]).To(Equal(`fail`))
+			Expect(ccWithBatch.Query(`get`, `item1`).Status).To(BeNumerically(`>=`, shim.ERRORTHRESHOLD))
+		})
+
+		It(`Disallow nested batch, init and query calls`, func() {
+			for _, call := range []router.BatchCall{
+				batchCall(router.BatchMethod, `[]`),
+				batchCall(router.InitFunc),
+				batchCall(`get`, `item1`),
+			} {
+				expectcc.ResponseErrorIs(ccWithBatch.Invoke(router.BatchMethod, []router.BatchCall{
+					batchCall(`put`, `item1`, `a`), call,
+				}), router.ErrBatchInvalid)
+			}
+		})
+
+		It(`Allow to handle every call with pre middleware`, func() {
+			responses := expectcc.PayloadIs(ccWithBatch.Invoke(router.BatchMethod, []router.BatchCall{
+				batchCall(`prePath`),
+			}), &[]peer.Response{}).([]peer.Response)
+			Expect(string(responses[0].Payload)).To(Equal(`prePath`))
+		})
+
+		It(`Allow to read private data, written by previous calls`, func() {
+			responses := expectcc.PayloadIs(ccWithBatch.Invoke(router.BatchMethod, []router.BatchCall{
+				batchCall(`putPrivate`, `key1`, `secret`),
+				batchCall(`getPrivate`, `key1`),
+			}), &[]peer.Response{}).([]peer.Response)
+			Expect(string(responses[1].Payload)).To(Equal(`secret`))
+		})
+
+		It(`Disallow stub methods, which can't see buffered writes`, func() {
+			expectcc.ResponseErrorIs(ccWithBatch.Invoke(router.BatchMethod, []router.BatchCall{
+				batchCall(`history`, `item1`),
+			}), router.ErrBatchNotSupported)
+		})
+	})
+
+	Describe(`Migrations`, func() {
+
+		ccWithMigrations := testcc.NewMockStub(`migrations`, NewWithMigrations(2))
+
+		upgrade := func(cc shim.Chaincode) *testcc.MockStub {
+			upgraded := testcc.NewMockStub(`migrations`, cc)
+			upgraded.State, upgraded.Keys = ccWithMigrations.State, ccWithMigrations.Keys
+			return upgraded
+		}
+
+		It(`Apply all migrations on first init`, func() {
+			expectcc.ResponseOk(ccWithMigrations.Init())
+			expectcc.PayloadInt(ccWithMigrations.Query(router.QuerySchemaVersionMethod), 2)
+		})
+
+		It(`Apply only pending migrations on upgrade`, func() {
+			ccWithMigrations = upgrade(NewWithMigrations(3))
+			expectcc.ResponseOk(ccWithMigrations.Init())
+
+			expectcc.PayloadInt(ccWithMigrations.Query(router.QuerySchemaVersionMethod), 3)
+			applied, _ := ccWithMigrations.GetStateByPartialCompositeKey(`applied`, []string{})
+			var count int
+			for ; applied.HasNext(); count++ {
+				kv, _ := applied.Next()
+				Expect(string(kv.Value)).To(Equal(`1`))
+			}
+			Expect(count).To(Equal(3))
+		})
+
+		It(`Abort init if migration fails`, func() {
+			failed := testcc.NewMockStub(`migrations`, NewWithMigrations(-1))
+			ccErr := expectcc.ResponseErrorIs(failed.Init(), router.ErrMigrationFailed)
+			Expect(ccErr.Details[`version`]).To(Equal(`1`))
+			Expect(ccErr.Message).To(Equal(router.ErrMigrationFailed.Error() + `: ` + ErrInsufficientFunds.Error()))
+			expectcc.PayloadInt(failed.Query(router.QuerySchemaVersionMethod), 0)
+		})
+
+		It(`Disallow to apply migrations on invoke of init method`, func() {
+			notInit := testcc.NewMockStub(`migrations`, NewWithMigrations(1))
+			expectcc.ResponseOk(notInit.Invoke(router.InitFunc))
+			expectcc.PayloadInt(notInit.Query(router.QuerySchemaVersionMethod), 0)
+		})
+	})
 })
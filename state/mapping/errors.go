@@ -19,4 +19,7 @@ var (
 
 	ErrFieldNotExists         = errors.New(`field is not exists`)
 	ErrPrimaryKeyerNotDefined = errors.New(`primary keyer is not defined`)
+
+	// ErrIdGeneratorNotDefined occurs when entry with generated primary key inserted without id generator
+	ErrIdGeneratorNotDefined = errors.New(`id generator is not defined`)
 )
@@ -10,6 +10,7 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/optherium/cckit/errors"
 	examplecert "github.com/optherium/cckit/examples/cert"
+	"github.com/optherium/cckit/router"
 	"github.com/optherium/cckit/state"
 	"github.com/optherium/cckit/state/mapping"
 	"github.com/optherium/cckit/state/mapping/testdata"
@@ -25,9 +26,9 @@ func TestState(t *testing.T) {
 }
 
 var (
-	actors                          testcc.Identities
-	protoCC, complexIDCC, sliceIDCC *testcc.MockStub
-	err                             error
+	actors                                       testcc.Identities
+	protoCC, complexIDCC, sliceIDCC, generatedCC *testcc.MockStub
+	err                                          error
 )
 var _ = Describe(`Mapping`, func() {
 
@@ -46,6 +47,9 @@ var _ = Describe(`Mapping`, func() {
 
 		sliceIDCC = testcc.NewMockStub(`sliceid`, testdata.NewSliceIdCC())
 		sliceIDCC.From(actors[`owner`]).Init()
+
+		generatedCC = testcc.NewMockStub(`generatedid`, testdata.NewGeneratedIdCC())
+		generatedCC.Init()
 	})
 
 	Describe(`Commercial paper extended, protobuf based schema with additional keys`, func() {
@@ -211,7 +215,7 @@ var _ = Describe(`Mapping`, func() {
 
 			// from hyperledger/fabric/core/chaincode/shim/chaincode.go
 			Expect(keys[0]).To(Equal(
-				"\x00" + `EntityWithComplexId` + string(0) + ent1.Id.IdPart1 + string(0) + ent1.Id.IdPart2 + string(0)))
+				"\x00" + `EntityWithComplexId` + "\x00" + ent1.Id.IdPart1 + "\x00" + ent1.Id.IdPart2 + "\x00"))
 		})
 
 		It("Allow to get entity", func() {
@@ -241,7 +245,7 @@ var _ = Describe(`Mapping`, func() {
 			Expect(len(keys)).To(Equal(1))
 
 			// from hyperledger/fabric/core/chaincode/shim/chaincode.go
-			Expect(keys[0]).To(Equal("\x00" + `EntityWithSliceId` + string(0) + ent2.Id[0] + string(0) + ent2.Id[1] + string(0)))
+			Expect(keys[0]).To(Equal("\x00" + `EntityWithSliceId` + "\x00" + ent2.Id[0] + "\x00" + ent2.Id[1] + "\x00"))
 		})
 
 		It("Allow to get entity", func() {
@@ -258,4 +262,37 @@ var _ = Describe(`Mapping`, func() {
 			Expect(listFromCC.Items[0].Value).To(Equal(testcc.MustProtoMarshal(ent2)))
 		})
 	})
+
+	Describe(`Entity with generated id`, func() {
+
+		var ids []string
+
+		It("Allow to insert entries with generated ordered ids", func() {
+			ids = expectcc.PayloadIs(generatedCC.Invoke(`entityInsert`, []string{`first`, `second`}), &[]string{}).([]string)
+			Expect(ids).To(HaveLen(2))
+			Expect(ids[0]).To(HaveLen(26))
+			Expect(ids[0] < ids[1]).To(BeTrue())
+
+			next := expectcc.PayloadIs(generatedCC.Invoke(`entityInsert`, []string{`third`}), &[]string{}).([]string)
+			Expect(ids).NotTo(ContainElement(next[0]))
+		})
+
+		It("Allow to get entity by generated id", func() {
+			entity := expectcc.PayloadIs(generatedCC.Query(`entityGet`, ids[1]),
+				&schema.EntityWithGeneratedId{}).(*schema.EntityWithGeneratedId)
+			Expect(entity.Id).To(Equal(ids[1]))
+			Expect(entity.Name).To(Equal(`second`))
+		})
+
+		It("Allow to rewrite mapped entities with migration on upgrade", func() {
+			upgraded := testcc.NewMockStub(`generatedid`, testdata.NewGeneratedIdCCUpgraded())
+			upgraded.State, upgraded.Keys = generatedCC.State, generatedCC.Keys
+			expectcc.ResponseOk(upgraded.Init())
+
+			expectcc.PayloadInt(upgraded.Query(router.QuerySchemaVersionMethod), 1)
+			entity := expectcc.PayloadIs(upgraded.Query(`entityGet`, ids[1]),
+				&schema.EntityWithGeneratedId{}).(*schema.EntityWithGeneratedId)
+			Expect(entity.Name).To(Equal(`SECOND`))
+		})
+	})
 })
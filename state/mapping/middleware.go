@@ -7,7 +7,7 @@ import (
 func MapStates(stateMappings StateMappings) router.MiddlewareFunc {
 	return func(next router.HandlerFunc, pos ...int) router.HandlerFunc {
 		return func(c router.Context) (interface{}, error) {
-			c.UseState(WrapState(c.State(), stateMappings))
+			c.UseState(WrapState(c.State(), stateMappings).UseIdGenerator(c.NewID))
 			return next(c)
 		}
 	}
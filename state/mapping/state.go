@@ -2,6 +2,7 @@ package mapping
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/pkg/errors"
@@ -23,9 +24,13 @@ type (
 		//GetByKey(schema interface{}, key string, keyValue []interface{}) (result interface{}, err error)
 	}
 
+	// IdGenerator returns unique id for new state entry
+	IdGenerator func() (string, error)
+
 	Impl struct {
-		state    state.State
-		mappings StateMappings
+		state       state.State
+		mappings    StateMappings
+		idGenerator IdGenerator
 	}
 )
 
@@ -48,6 +53,12 @@ func WrapState(s state.State, mappings StateMappings) *Impl {
 	}
 }
 
+// UseIdGenerator sets generator for mappings with generated primary key
+func (s *Impl) UseIdGenerator(idGenerator IdGenerator) *Impl {
+	s.idGenerator = idGenerator
+	return s
+}
+
 func (s *Impl) MappingNamespace(schema interface{}) (state.Key, error) {
 	m, err := s.mappings.Get(schema)
 	if err != nil {
@@ -123,6 +134,10 @@ func (s *Impl) Put(entry interface{}, value ...interface{}) error {
 }
 
 func (s *Impl) Insert(entry interface{}, value ...interface{}) error {
+	if err := s.generateId(entry); err != nil {
+		return err
+	}
+
 	mapped, err := s.mappings.Map(entry)
 	if err != nil { // mapping is not exists
 		return s.state.Insert(entry, value...) // return as is
@@ -325,3 +340,29 @@ func (s *Impl) ExistsPrivate(collection string, entry interface{}) (exists bool,
 
 	return s.state.ExistsPrivate(collection, mapped)
 }
+
+// generateId fills empty Id attr of entry if mapping has generated primary key
+func (s *Impl) generateId(entry interface{}) error {
+	mapper, err := s.mappings.Get(entry)
+	if err != nil || !mapper.IdGenerated() {
+		return nil
+	}
+
+	id := reflect.Indirect(reflect.ValueOf(entry)).FieldByName(`Id`)
+	if !id.IsValid() || id.Kind() != reflect.String {
+		return fmt.Errorf(`%s: %s.Id`, ErrFieldTypeNotSupportedForKeyExtraction, mapKey(entry))
+	}
+	if id.String() != `` {
+		return nil
+	}
+
+	if s.idGenerator == nil {
+		return ErrIdGeneratorNotDefined
+	}
+	newId, err := s.idGenerator()
+	if err != nil {
+		return errors.Wrap(err, `generate id`)
+	}
+	id.SetString(newId)
+	return nil
+}
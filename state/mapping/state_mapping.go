@@ -27,6 +27,8 @@ type (
 		PrimaryKey(instance interface{}) (key state.Key, err error)
 		Keys(instance interface{}) (key []state.KeyValue, err error)
 		KeyerFor() interface{}
+		// IdGenerated returns true if empty Id attr should be filled with generated id on insert
+		IdGenerated() bool
 	}
 
 	// InstanceKeyer returns key of an state entry instance
@@ -45,6 +47,7 @@ type (
 		primaryKeyer   InstanceKeyer
 		list           interface{}
 		uniqKeys       []*StateKeyDefinition
+		idGenerated    bool
 	}
 
 	// StateKeyDefinition
@@ -190,3 +193,7 @@ func (sm *StateMapping) Keys(entity interface{}) (kv []state.KeyValue, err error
 func (sm *StateMapping) KeyerFor() interface{} {
 	return sm.keyerForSchema
 }
+
+func (sm *StateMapping) IdGenerated() bool {
+	return sm.idGenerated
+}
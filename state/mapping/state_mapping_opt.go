@@ -63,6 +63,15 @@ func PKeyId() StateMappingOpt {
 	return PKeyAttr(`Id`)
 }
 
+// PKeyGenerated use Id attr as source for mapped state entry key,
+// empty Id is filled on Insert with id from IdGenerator (router.Context NewID when state mapped with MapStates)
+func PKeyGenerated() StateMappingOpt {
+	return func(sm *StateMapping, smm StateMappings) {
+		sm.primaryKeyer = attrsPKeyer([]string{`Id`})
+		sm.idGenerated = true
+	}
+}
+
 // PKeyConst use constant as state entry key
 func PKeyConst(key state.Key) StateMappingOpt {
 	return func(sm *StateMapping, smm StateMappings) {
@@ -0,0 +1,70 @@
+package testdata
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/optherium/cckit/router"
+	"github.com/optherium/cckit/router/param"
+	m "github.com/optherium/cckit/state/mapping"
+	"github.com/optherium/cckit/state/mapping/testdata/schema"
+	state_schema "github.com/optherium/cckit/state/schema"
+)
+
+func NewGeneratedIdCC() *router.Chaincode {
+	return router.NewChaincode(newGeneratedIdRouter())
+}
+
+// NewGeneratedIdCCUpgraded chaincode with migration of entities, inserted by NewGeneratedIdCC
+func NewGeneratedIdCCUpgraded() *router.Chaincode {
+	r := newGeneratedIdRouter()
+
+	r.Migrations().Add(1, func(c router.Context) error {
+		entities, err := c.State().List(&schema.EntityWithGeneratedId{})
+		if err != nil {
+			return err
+		}
+		for _, item := range entities.(*state_schema.List).Items {
+			entity := &schema.EntityWithGeneratedId{}
+			if err = proto.Unmarshal(item.Value, entity); err != nil {
+				return err
+			}
+			entity.Name = strings.ToUpper(entity.Name)
+			if err = c.State().Put(entity); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return router.NewChaincode(r)
+}
+
+func newGeneratedIdRouter() *router.Group {
+	r := router.New(`generatedId`)
+
+	// Mappings for chaincode state
+	r.Use(m.MapStates(m.StateMappings{}.
+		//key will be <`EntityWithGeneratedId`, {Id} >, Id generated on insert if empty
+		Add(&schema.EntityWithGeneratedId{}, m.PKeyGenerated())))
+
+	r.Init(router.EmptyContextHandler)
+
+	r.Group(`entity`).
+		Query(`Get`, func(c router.Context) (interface{}, error) {
+			return c.State().Get(&schema.EntityWithGeneratedId{Id: c.ParamString(`Id`)})
+		}, param.String(`Id`)).
+		Invoke(`Insert`, func(c router.Context) (interface{}, error) {
+			var ids []string
+			for _, name := range c.Param(`names`).([]string) {
+				entity := &schema.EntityWithGeneratedId{Name: name}
+				if err := c.State().Insert(entity); err != nil {
+					return nil, err
+				}
+				ids = append(ids, entity.Id)
+			}
+			return ids, nil
+		}, param.Strings(`names`))
+
+	return r
+}
@@ -8,6 +8,7 @@ It is generated from these files:
 	complex_id.proto
 	proto_schema.proto
 	slice_id.proto
+	ulid_id.proto
 
 It has these top-level messages:
 	EntityWithComplexId
@@ -18,6 +19,7 @@ It has these top-level messages:
 	IssueProtoEntity
 	IncrementProtoEntity
 	EntityWithSliceId
+	EntityWithGeneratedId
 */
 package schema
 
@@ -9,6 +9,7 @@ import (
 	"github.com/hyperledger/fabric/protos/peer"
 	g "github.com/onsi/gomega"
 	"github.com/optherium/cckit/convert"
+	"github.com/optherium/cckit/errors"
 )
 
 // ResponseOk expects peer.Response has shim.OK status and message has okSubstr prefix
@@ -39,6 +40,19 @@ func ResponseErrorWithCode(response peer.Response, error interface{}, code int32
 		"error message not match: "+response.Message)
 }
 
+// ResponseErrorIs expects peer.Response has error status, decodes structured error from payload
+// and expects it matches target error (CCError with same reason)
+func ResponseErrorIs(response peer.Response, target error) *errors.CCError {
+	g.Expect(response.Status >= shim.ERRORTHRESHOLD).To(g.BeTrue(), `error response expected: `+response.Message)
+
+	err := errors.FromResponse(response)
+	g.Expect(errors.Is(err, target)).To(g.BeTrue(),
+		fmt.Sprintf(`error not match: %s, expected: %s`, err, target))
+
+	ccErr, _ := err.(*errors.CCError)
+	return ccErr
+}
+
 // PayloadIs expects peer.Response payload can be marshalled to target interface{} and returns converted value
 func PayloadIs(response peer.Response, target interface{}) interface{} {
 	ResponseOk(response)
@@ -1,10 +1,18 @@
 package testing
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
 	"encoding/pem"
+	"math/big"
 	"time"
 
+	"github.com/hyperledger/fabric/common/attrmgr"
+
 	msppb "github.com/hyperledger/fabric/protos/msp"
 	"github.com/optherium/cckit/identity"
 
@@ -83,6 +91,56 @@ func MustIdentitiesFromFiles(mspID string, files map[string]string, getContent i
 	}
 }
 
+// GenerateIdentity creates identity with self-signed certificate, attrs are added to certificate
+// as Fabric CA attributes extension
+func GenerateIdentity(mspID string, subject pkix.Name, attrs map[string]string) (*Identity, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, `generate key`)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, errors.Wrap(err, `generate serial`)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      subject,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * 365 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+
+	if len(attrs) > 0 {
+		attrsJSON, err := json.Marshal(&attrmgr.Attributes{Attrs: attrs})
+		if err != nil {
+			return nil, errors.Wrap(err, `marshal attributes`)
+		}
+		template.ExtraExtensions = []pkix.Extension{{Id: attrmgr.AttrOID, Value: attrsJSON}}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, errors.Wrap(err, `create certificate`)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	return NewIdentity(mspID, cert), nil
+}
+
+// MustGenerateIdentity creates identity with self-signed certificate and attributes, panics on error
+func MustGenerateIdentity(mspID string, subject pkix.Name, attrs map[string]string) *Identity {
+	id, err := GenerateIdentity(mspID, subject, attrs)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
 func NewIdentity(mspID string, cert *x509.Certificate) *Identity {
 	return &Identity{
 		MspId:       mspID,
@@ -119,6 +119,13 @@ func (stub *MockStub) MockedPeerChaincodes() []string {
 
 // InvokeChaincode using another MockStub
 func (stub *MockStub) InvokeChaincode(chaincodeName string, args [][]byte, channel string) peer.Response {
+	return stub.InvokeChaincodeWithTransient(chaincodeName, args, channel, stub.transient)
+}
+
+// InvokeChaincodeWithTransient invokes mocked chaincode with transient map, other than tx transient map.
+// Unlike peer, where called chaincode receives signed proposal of current tx
+func (stub *MockStub) InvokeChaincodeWithTransient(
+	chaincodeName string, args [][]byte, channel string, transient map[string][]byte) peer.Response {
 	// Internally we use chaincode name as a composite name
 	ccName := chaincodeName
 	if channel != "" {
@@ -132,8 +139,15 @@ func (stub *MockStub) InvokeChaincode(chaincodeName string, args [][]byte, chann
 			ErrChaincodeNotExists, ccName, channel, chaincodeName, stub.MockedPeerChaincodes()))
 	}
 
-	res := otherStub.MockInvoke(stub.TxID, args)
-	return res
+	// called chaincode receives same creator, creator and transient map of called stub are restored after call
+	creator, calleeTransient := otherStub.mockCreator, otherStub.transient
+	defer func() {
+		otherStub.mockCreator, otherStub.transient = creator, calleeTransient
+	}()
+	otherStub.mockCreator = stub.mockCreator
+	otherStub.transient = transient
+
+	return otherStub.MockInvoke(stub.TxID, args)
 }
 
 // GetFunctionAndParameters mocked